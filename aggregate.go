@@ -0,0 +1,244 @@
+package table
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// An Aggregate summarizes the numeric values of column Col, like their sum.
+// Name is used in headers, like "sum(size)".
+type Aggregate struct {
+	Name string
+	Col  int
+	Fn   func(values []float64) float64
+}
+
+// Sum returns an Aggregate adding up the values of column col.
+func Sum(col int) Aggregate {
+	return Aggregate{Name: "sum", Col: col, Fn: func(values []float64) float64 {
+		var s float64
+		for _, v := range values {
+			s += v
+		}
+		return s
+	}}
+}
+
+// Mean returns an Aggregate averaging the values of column col.
+func Mean(col int) Aggregate {
+	sum := Sum(col).Fn
+	return Aggregate{Name: "mean", Col: col, Fn: func(values []float64) float64 {
+		if len(values) == 0 {
+			return math.NaN()
+		}
+		return sum(values) / float64(len(values))
+	}}
+}
+
+// Min returns an Aggregate finding the smallest value of column col.
+func Min(col int) Aggregate {
+	return Aggregate{Name: "min", Col: col, Fn: func(values []float64) float64 {
+		m := math.Inf(1)
+		for _, v := range values {
+			m = math.Min(m, v)
+		}
+		return m
+	}}
+}
+
+// Max returns an Aggregate finding the largest value of column col.
+func Max(col int) Aggregate {
+	return Aggregate{Name: "max", Col: col, Fn: func(values []float64) float64 {
+		m := math.Inf(-1)
+		for _, v := range values {
+			m = math.Max(m, v)
+		}
+		return m
+	}}
+}
+
+// Quantile returns an Aggregate finding the q quantile of the values of column col, from 0 to 1,
+// interpolating between the closest values. Its name is the percentile, like "p95" for 0.95.
+func Quantile(col int, q float64) Aggregate {
+	q = math.Max(0, math.Min(1, q))
+	name := "p" + strconv.FormatFloat(q*100, 'f', -1, 64)
+	return Aggregate{Name: name, Col: col, Fn: func(values []float64) float64 {
+		if len(values) == 0 {
+			return math.NaN()
+		}
+		sorted := append([]float64(nil), values...)
+		sort.Float64s(sorted)
+		h := q * float64(len(sorted)-1)
+		lo := int(h)
+		if lo == len(sorted)-1 {
+			return sorted[lo]
+		}
+		return sorted[lo] + (h-float64(lo))*(sorted[lo+1]-sorted[lo])
+	}}
+}
+
+// P50 returns an Aggregate finding the median of the values of column col.
+func P50(col int) Aggregate {
+	return Quantile(col, 0.5)
+}
+
+// P90 returns an Aggregate finding the 90th percentile of the values of column col.
+func P90(col int) Aggregate {
+	return Quantile(col, 0.9)
+}
+
+// P99 returns an Aggregate finding the 99th percentile of the values of column col.
+func P99(col int) Aggregate {
+	return Quantile(col, 0.99)
+}
+
+// StdDev returns an Aggregate finding the population standard deviation of the values of column col.
+func StdDev(col int) Aggregate {
+	mean := Mean(col).Fn
+	return Aggregate{Name: "stddev", Col: col, Fn: func(values []float64) float64 {
+		if len(values) == 0 {
+			return math.NaN()
+		}
+		m := mean(values)
+		var s float64
+		for _, v := range values {
+			s += (v - m) * (v - m)
+		}
+		return math.Sqrt(s / float64(len(values)))
+	}}
+}
+
+// FooterAggregates adds footer rows with the aggregates of the rows added so far, one row per
+// aggregate name in the order first listed, like "p50" then "p99", holding the aggregates of that
+// name in their columns. The name is printed in the first column unless an aggregate uses it.
+// Values are converted as set by Precision.
+func (t *Table) FooterAggregates(aggs ...Aggregate) {
+	t.compute()
+	rows := make([]*row, 0, len(t.rows))
+	for j := range t.rows {
+		if !t.rows[j].blank && !t.rows[j].removed {
+			rows = append(rows, &t.rows[j])
+		}
+	}
+	var names []string
+	byName := make(map[string][]Aggregate)
+	for _, a := range aggs {
+		if !t.validCol(a.Col) {
+			continue
+		}
+		if _, ok := byName[a.Name]; !ok {
+			names = append(names, a.Name)
+		}
+		byName[a.Name] = append(byName[a.Name], a)
+	}
+	for _, name := range names {
+		values := make([]interface{}, t.columns)
+		values[0] = name
+		for _, a := range byName[name] {
+			values[a.Col] = a.aggregate(rows)
+		}
+		t.Footer(values...)
+	}
+}
+
+// header returns the header of the column of aggregate a in table t.
+func (a Aggregate) header(t *Table) string {
+	return a.Name + "(" + t.headers[a.Col] + ")"
+}
+
+// aggregate returns the aggregate of the numeric values of rows, or nil if there are none.
+func (a Aggregate) aggregate(rows []*row) interface{} {
+	var values []float64
+	for _, r := range rows {
+		if f, ok := toFloat(r.value(a.Col)); ok {
+			values = append(values, f)
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return a.Fn(values)
+}
+
+// GroupByTime returns a summary of the table with one row per time bucket of column col,
+// like one row per hour of log entries. Its columns hold the start of each bucket, the number of
+// rows and the listed aggregates. Buckets are sorted by time and rows without a time.Time value
+// in column col are skipped. Buckets of whole days start at midnight in the location of the
+// times, and weeks on Monday. Aggregates of invalid columns are left out, and an invalid col
+// returns a table without columns.
+func (t *Table) GroupByTime(col int, bucket time.Duration, aggs ...Aggregate) *Table {
+	if !t.validCol(col) {
+		return New()
+	}
+	headers := []string{t.headers[col], "count"}
+	valid := make([]Aggregate, 0, len(aggs))
+	for _, a := range aggs {
+		if t.validCol(a.Col) {
+			valid = append(valid, a)
+			headers = append(headers, a.header(t))
+		}
+	}
+	aggs = valid
+	s := New(headers...)
+	if bucket <= 0 {
+		return s
+	}
+	t.compute()
+	groups := make(map[time.Time][]*row)
+	var starts []time.Time
+	for j := range t.rows {
+		tm, ok := t.rows[j].value(col).(time.Time)
+		if !ok || t.rows[j].removed {
+			continue
+		}
+		start := bucketStart(tm, bucket)
+		if _, ok := groups[start]; !ok {
+			starts = append(starts, start)
+		}
+		groups[start] = append(groups[start], &t.rows[j])
+	}
+	sort.Slice(starts, func(i, j int) bool {
+		return starts[i].Before(starts[j])
+	})
+	layout := bucketLayout(bucket)
+	s.convert[0] = func(v interface{}, p int) (string, bool) {
+		tm, ok := v.(time.Time)
+		return tm.Format(layout), ok
+	}
+	for _, start := range starts {
+		rows := groups[start]
+		values := []interface{}{start, len(rows)}
+		for _, a := range aggs {
+			values = append(values, a.aggregate(rows))
+		}
+		s.Row(values...)
+	}
+	return s
+}
+
+// bucketStart returns the start of the bucket of the given duration holding tm. Buckets of whole
+// days start at midnight in the location of tm, not in UTC like those of time.Truncate, and
+// weeks on Monday.
+func bucketStart(tm time.Time, bucket time.Duration) time.Time {
+	const day = 24 * time.Hour
+	if bucket%day != 0 {
+		return tm.Truncate(bucket)
+	}
+	y, m, d := tm.Date()
+	// days since January 1 of year 1, a Monday, like the zero time time.Truncate counts from
+	n := (time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() - time.Time{}.Unix()) / int64(day/time.Second)
+	return time.Date(y, m, d-int(n%int64(bucket/day)), 0, 0, 0, 0, tm.Location())
+}
+
+// bucketLayout returns a time layout showing the start of buckets of the given duration.
+func bucketLayout(bucket time.Duration) string {
+	switch {
+	case bucket%(24*time.Hour) == 0:
+		return "2006-01-02"
+	case bucket%time.Minute == 0:
+		return "2006-01-02 15:04"
+	}
+	return "2006-01-02 15:04:05"
+}
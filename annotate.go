@@ -0,0 +1,78 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Annotate attaches a short annotation to the cell of column col in the row at index row, like
+// the source of a value or why it is estimated. Use row index -1 to denote the last row.
+// The cell is printed with a numbered marker, like "42¹", and the annotations are listed with
+// their markers below the table. PrintHTML prints them as tooltips instead.
+// Cells with the same annotation share a marker. The annotation stays with the row when sorted.
+func (t *Table) Annotate(row, col int, text string) {
+	r := t.rowAt(row)
+	if r == nil || !t.validCol(col) {
+		return
+	}
+	r.annotations = withString(r.annotations, col, text)
+}
+
+// Annotation returns the annotation of the cell of column col in the row at index row, if any.
+func (t *Table) Annotation(row, col int) string {
+	if r := t.rowAt(row); r != nil {
+		return r.annotations[col]
+	}
+	return ""
+}
+
+// annotate sets the markers of the annotated cells of v and the legend listing the annotations,
+// numbered in print order.
+func (t *Table) annotate(v *view) {
+	numbers := make(map[string]int)
+	for j, n := range v.rows {
+		a := t.rows[n].annotations
+		if len(a) == 0 {
+			continue
+		}
+		for k, i := range v.src {
+			text, ok := a[i]
+			if !ok || i == indexCol {
+				continue
+			}
+			num, ok := numbers[text]
+			if !ok {
+				num = len(numbers) + 1
+				numbers[text] = num
+				v.legend = append(v.legend, superscript(num)+" "+text)
+			}
+			if v.marks == nil {
+				v.marks = make([][]string, len(v.cells))
+			}
+			if v.marks[j] == nil {
+				v.marks[j] = make([]string, len(v.src))
+			}
+			v.marks[j][k] = superscript(num)
+		}
+	}
+}
+
+// mark returns the marker of printed cell k of printed row j, if annotated.
+func (v *view) mark(j, k int) string {
+	if j < len(v.marks) && v.marks[j] != nil {
+		return v.marks[j][k]
+	}
+	return ""
+}
+
+// superscripts holds the superscript digits.
+var superscripts = []rune("⁰¹²³⁴⁵⁶⁷⁸⁹")
+
+// superscript returns n written in superscript digits.
+func superscript(n int) string {
+	var b strings.Builder
+	for _, d := range strconv.Itoa(n) {
+		b.WriteRune(superscripts[d-'0'])
+	}
+	return b.String()
+}
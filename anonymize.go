@@ -0,0 +1,62 @@
+package table
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// anonymizer replaces the values of a column when printing.
+type anonymizer struct {
+	salt   string
+	pseudo bool // use sequential pseudonyms instead of hashes
+}
+
+// Anonymize replaces the values of the listed columns by a short hash of the value and salt when printing,
+// so that tables with identifiers like customer names can be shared. Equal values get equal hashes,
+// and keeping the salt secret prevents guessing the values back. Empty values are left empty.
+func (t *Table) Anonymize(salt string, cols ...int) {
+	for _, col := range cols {
+		if t.validCol(col) {
+			t.anonymize[col] = anonymizer{salt: salt}
+		}
+	}
+}
+
+// Pseudonymize replaces the values of the listed columns by sequential pseudonyms like "name-1" when printing,
+// numbered in order of first appearance. Equal values get equal pseudonyms. Empty values are left empty.
+func (t *Table) Pseudonymize(cols ...int) {
+	for _, col := range cols {
+		if t.validCol(col) {
+			t.anonymize[col] = anonymizer{pseudo: true}
+		}
+	}
+}
+
+// anonymizeView replaces the cells of all anonymized columns of v.
+func (t *Table) anonymizeView(v *view) {
+	for k, i := range v.src {
+		a, ok := t.anonymize[i]
+		if !ok {
+			continue
+		}
+		seen := make(map[string]string)
+		for _, cells := range v.cells {
+			c := cells[k]
+			if c == "" {
+				continue
+			}
+			s, ok := seen[c]
+			if !ok {
+				if a.pseudo {
+					s = t.headers[i] + "-" + strconv.Itoa(len(seen)+1)
+				} else {
+					h := sha256.Sum256([]byte(a.salt + c))
+					s = hex.EncodeToString(h[:4])
+				}
+				seen[c] = s
+			}
+			cells[k] = s
+		}
+	}
+}
@@ -3,6 +3,7 @@ package table
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 // CodeANSI is an ANSI escape code attribute
@@ -73,3 +74,92 @@ const (
 func Background(c CodeANSI) CodeANSI {
 	return CodeANSI(int(c) + colorBgAdd)
 }
+
+// stripANSI removes all ANSI escape sequences from s.
+func stripANSI(s string) string {
+	if strings.IndexByte(s, '\x1b') < 0 {
+		return s
+	}
+	b := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\x1b' {
+			b = append(b, s[i])
+			continue
+		}
+		i = skipEscape(s, i)
+	}
+	return string(b)
+}
+
+// skipEscape returns the index of the last byte of the escape sequence starting at s[i].
+func skipEscape(s string, i int) int {
+	if i+1 < len(s) && s[i+1] == '[' {
+		// CSI sequence, ended by a byte in the range 0x40-0x7e
+		for i += 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i
+			}
+		}
+		return len(s) - 1
+	}
+	if i+1 < len(s) && s[i+1] == ']' {
+		// OSC sequence, like a hyperlink, ended by BEL or ESC \
+		for i += 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 1
+			}
+		}
+		return len(s) - 1
+	}
+	// two byte sequence
+	if i+1 < len(s) {
+		return i + 1
+	}
+	return i
+}
+
+// A Style describes the colors and decorations of a value in a structured way.
+// The text renderer applies it with ANSI escape codes, while other renderers may translate it
+// into their own styling. The zero Style leaves values unchanged.
+type Style struct {
+	// Fg is the foreground color, or 0 for the default color.
+	Fg CodeANSI
+	// Bg is the background color given as a foreground color like Blue, or 0 for the default color.
+	Bg CodeANSI
+	// Attrs holds decorations like Bold or Underline.
+	Attrs []CodeANSI
+}
+
+// codes returns the ANSI escape code attributes of s.
+func (s Style) codes() []CodeANSI {
+	var codes []CodeANSI
+	if s.Fg != 0 {
+		codes = append(codes, s.Fg)
+	}
+	if s.Bg != 0 {
+		codes = append(codes, Background(s.Bg))
+	}
+	return append(codes, s.Attrs...)
+}
+
+// Format returns a formatting function applying s with ANSI escape codes.
+func (s Style) Format() FormatFunc {
+	codes := s.codes()
+	if len(codes) == 0 {
+		return func(v string) string { return v }
+	}
+	return Format(codes...)
+}
+
+// styled holds a style together with its format function.
+type styled struct {
+	Style
+	fn FormatFunc
+}
+
+func newStyled(s Style) *styled {
+	return &styled{Style: s, fn: s.Format()}
+}
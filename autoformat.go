@@ -0,0 +1,115 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A converter converts a row value to a string, printing floats with p digits.
+// It returns false if it does not handle the value.
+type converter func(v interface{}, p int) (string, bool)
+
+// AutoFormat sets how values are converted to strings based on the column header names,
+// saving per-column setup in large command line applications:
+//
+//	"*_bytes", "bytes"        sizes like 1536 print as "1.5 KiB"
+//	"*_at", "*_time", "time"  time.Time values print relative to the time of printing, like "5m ago"
+//	"*_pct", "pct"            numbers print as percentages, like "12.50%"
+//
+// Header names are matched case insensitively. Values of other types than expected are converted as usual.
+// It must be called before adding the rows.
+func (t *Table) AutoFormat() {
+	for i, h := range t.headers {
+		var c converter
+		h = strings.ToLower(h)
+		switch {
+		case h == "bytes" || strings.HasSuffix(h, "_bytes"):
+			c = humanBytes
+		case h == "time" || strings.HasSuffix(h, "_time") || strings.HasSuffix(h, "_at"):
+			// converted again when printed, so that repeated prints stay current
+			c = relativeTime
+			t.relative[i] = true
+		case h == "pct" || strings.HasSuffix(h, "_pct"):
+			c = percentValue
+		}
+		if c != nil {
+			t.convert[i] = c
+			delete(t.locales, i)
+		}
+	}
+}
+
+var byteUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// humanBytes converts a number of bytes to a human readable size.
+func humanBytes(v interface{}, p int) (string, bool) {
+	if _, ok := v.(string); ok {
+		return "", false
+	}
+	f, ok := toFloat(v)
+	if !ok {
+		return "", false
+	}
+	u := 0
+	for (f >= 1024 || f <= -1024) && u < len(byteUnits)-1 {
+		f /= 1024
+		u++
+	}
+	if u == 0 {
+		return strconv.FormatFloat(f, 'f', -1, 64) + " B", true
+	}
+	return strconv.FormatFloat(f, 'f', 1, 64) + " " + byteUnits[u], true
+}
+
+// relativeTime converts a time.Time to a duration relative to now.
+func relativeTime(v interface{}, p int) (string, bool) {
+	tm, ok := v.(time.Time)
+	if !ok {
+		return "", false
+	}
+	if tm.IsZero() {
+		return "", true
+	}
+	d := time.Since(tm)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	var s string
+	switch {
+	case d < time.Minute:
+		s = strconv.Itoa(int(d/time.Second)) + "s"
+	case d < time.Hour:
+		s = strconv.Itoa(int(d/time.Minute)) + "m"
+	case d < 24*time.Hour:
+		s = strconv.Itoa(int(d/time.Hour)) + "h"
+	default:
+		s = strconv.Itoa(int(d/(24*time.Hour))) + "d"
+	}
+	if future {
+		return "in " + s, true
+	}
+	return s + " ago", true
+}
+
+// relativeCell returns column i of row r converted relative to now, if it is a time.Time
+// printed relative to the time of printing.
+func (t *Table) relativeCell(r *row, i int) (string, bool) {
+	if !t.relative[i] {
+		return "", false
+	}
+	return relativeTime(r.value(i), 0)
+}
+
+// percentValue converts a number to a percentage.
+func percentValue(v interface{}, p int) (string, bool) {
+	if _, ok := v.(string); ok {
+		return "", false
+	}
+	f, ok := toFloat(v)
+	if !ok {
+		return "", false
+	}
+	return strconv.FormatFloat(f, 'f', p, 64) + "%", true
+}
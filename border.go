@@ -0,0 +1,79 @@
+package table
+
+import "strings"
+
+// BorderStyle is the style of the lines drawn around and between the cells of a table.
+type BorderStyle int
+
+// Border styles
+const (
+	// BorderNone separates columns with whitespace only.
+	BorderNone BorderStyle = iota
+	// BorderASCII draws a grid of plain ASCII characters, like +---+---+.
+	BorderASCII
+	// BorderBox draws a grid of box drawing characters, like ┌───┬───┐.
+	BorderBox
+	// BorderRounded draws a grid of box drawing characters with rounded corners, like ╭───┬───╮.
+	BorderRounded
+	// BorderDouble draws a grid of double box drawing characters, like ╔═══╦═══╗.
+	BorderDouble
+)
+
+// borderChars holds the characters of a border style. The corners and junctions are named after
+// their row (top, mid, bottom) and column (left, mid, right).
+type borderChars struct {
+	h, v       string
+	tl, tm, tr string
+	ml, mm, mr string
+	bl, bm, br string
+}
+
+var borders = map[BorderStyle]borderChars{
+	BorderASCII:   {"-", "|", "+", "+", "+", "+", "+", "+", "+", "+", "+"},
+	BorderBox:     {"─", "│", "┌", "┬", "┐", "├", "┼", "┤", "└", "┴", "┘"},
+	BorderRounded: {"─", "│", "╭", "┬", "╮", "├", "┼", "┤", "╰", "┴", "╯"},
+	BorderDouble:  {"═", "║", "╔", "╦", "╗", "╠", "╬", "╣", "╚", "╩", "╝"},
+}
+
+// Borders draws lines in the given style around the table, below the header and between the
+// columns. Separators and group gaps set to GapRule are drawn as lines across the grid, blank
+// group gaps as empty rows. Each cell is surrounded by one space on either side, instead of
+// the padding set with Padding. The bottom line is only printed by Print, not by PrintRows.
+func (t *Table) Borders(style BorderStyle) {
+	t.border = style
+}
+
+// bordered reports whether the table is printed with borders.
+func (t *Table) bordered() bool {
+	_, ok := borders[t.border]
+	return ok
+}
+
+// borderWidth returns the total width of the borders and cell margins of the printed columns.
+func borderWidth(v *view) int {
+	return 3*len(v.widths) + 1
+}
+
+// borderString applies the border format, if any, to s.
+func (t *Table) borderString(s string) string {
+	if t.formatBorder != nil {
+		return t.formatBorder(s)
+	}
+	return s
+}
+
+// printBorder prints a horizontal border line, with left, mid and right as the junction characters.
+func (t *Table) printBorder(p *printer, v *view, left, mid, right string) {
+	h := borders[t.border].h
+	var b strings.Builder
+	b.WriteString(left)
+	for k, w := range v.widths {
+		if k > 0 {
+			b.WriteString(mid)
+		}
+		b.WriteString(strings.Repeat(h, w+2))
+	}
+	b.WriteString(right)
+	p.writeString(t.borderString(b.String()))
+	p.endLine()
+}
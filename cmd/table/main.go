@@ -0,0 +1,319 @@
+// Command table pretty-prints CSV, TSV or JSON data read from stdin as a table.
+//
+// Usage:
+//
+//	table [flags] < data.csv
+//
+// JSON input must be an array of objects, whose keys become the table headers.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jayloop/table"
+)
+
+func main() {
+	var (
+		format   = flag.String("format", "", "input format: csv, tsv or json (detected if empty)")
+		sortBy   = flag.String("sort", "", "comma separated list of columns to sort by, by header or index")
+		filter   = flag.String("filter", "", "comma separated list of column=value conditions rows must match")
+		maxWidth = flag.Int("max-width", 0, "max width in characters of each column")
+		null     = flag.String("null", "", "CSV and TSV field value read as NULL, like NULL or \\N")
+		nullText = flag.String("null-text", "", "text printed for NULL values and missing fields")
+		theme    = flag.String("theme", "", "theme: plain, color, dark, light or solarized (plain if not set by the profile)")
+		profile  = flag.String("profile", "", "JSON file of appearance settings, as table.Profile")
+	)
+	flag.Parse()
+	p, err := loadProfile(*profile)
+	if err == nil {
+		if *theme != "" {
+			p.Theme = *theme
+		}
+		err = run(os.Stdin, os.Stdout, *format, *sortBy, *filter, *maxWidth, *null, *nullText, p)
+	}
+	if err != nil {
+		// errors of the table package already start with its name
+		fmt.Fprintln(os.Stderr, "table:", strings.TrimPrefix(err.Error(), "table: "))
+		os.Exit(1)
+	}
+}
+
+// loadProfile reads the profile in file, if any.
+func loadProfile(file string) (table.Profile, error) {
+	var p table.Profile
+	if file == "" {
+		return p, nil
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return p, err
+	}
+	err = json.Unmarshal(data, &p)
+	return p, err
+}
+
+func run(in io.Reader, out io.Writer, format, sortBy, filter string, maxWidth int, null, nullText string, p table.Profile) error {
+	data, err := ioutil.ReadAll(bufio.NewReader(in))
+	if err != nil {
+		return err
+	}
+	if format == "" {
+		format = detect(data)
+	}
+	var headers []string
+	var rows [][]interface{}
+	switch format {
+	case "csv":
+		headers, rows, err = readCSV(data, ',', null)
+	case "tsv":
+		headers, rows, err = readCSV(data, '\t', null)
+	case "json":
+		headers, rows, err = readJSON(data)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+	if err != nil {
+		return err
+	}
+	cond, err := parseFilter(headers, filter)
+	if err != nil {
+		return err
+	}
+	t := table.New(headers...)
+	// fields are read as strings, so number columns sort by value and align right as set here
+	for _, i := range numericColumns(len(headers), rows) {
+		t.Align(table.Right, i)
+		t.SortKey(i, numberKey)
+	}
+	if nullText != "" {
+		all := make([]int, len(headers))
+		for i := range all {
+			all[i] = i
+		}
+		t.NullValue(nullText, nil, all...)
+	}
+	if err := t.ApplyProfile(p); err != nil {
+		return err
+	}
+	if maxWidth > 0 {
+		for i := range headers {
+			t.MaxWidth(maxWidth, i)
+		}
+	}
+	for _, r := range rows {
+		if cond(r) {
+			t.Row(r...)
+		}
+	}
+	if sortBy != "" {
+		cols, err := columns(headers, sortBy)
+		if err != nil {
+			return err
+		}
+		t.Sort(cols...)
+	}
+	return t.Print(out)
+}
+
+// detect guesses the format of data from its first line.
+func detect(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{') {
+		return "json"
+	}
+	first := trimmed
+	if i := bytes.IndexByte(first, '\n'); i >= 0 {
+		first = first[:i]
+	}
+	if bytes.Count(first, []byte("\t")) > bytes.Count(first, []byte(",")) {
+		return "tsv"
+	}
+	return "csv"
+}
+
+// readCSV reads records with a header line. Fields equal to null, if set, are read as nil.
+func readCSV(data []byte, delimiter rune, null string) ([]string, [][]interface{}, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = delimiter
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, errors.New("no input")
+	}
+	rows := make([][]interface{}, len(records)-1)
+	for j, record := range records[1:] {
+		rows[j] = make([]interface{}, len(record))
+		for i, f := range record {
+			if null == "" || f != null {
+				rows[j][i] = f
+			}
+		}
+	}
+	return records[0], rows, nil
+}
+
+// readJSON reads an array of objects, keeping the order of the keys as they first appear.
+// Null values and missing keys are read as nil.
+func readJSON(data []byte) ([]string, [][]interface{}, error) {
+	var objects []json.RawMessage
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return nil, nil, err
+	}
+	var headers []string
+	index := make(map[string]int)
+	var rows [][]interface{}
+	for _, o := range objects {
+		d := json.NewDecoder(bytes.NewReader(o))
+		d.UseNumber()
+		if tok, err := d.Token(); err != nil || tok != json.Delim('{') {
+			return nil, nil, errors.New("json input must be an array of objects")
+		}
+		row := make([]interface{}, len(headers))
+		for d.More() {
+			tok, err := d.Token()
+			if err != nil {
+				return nil, nil, err
+			}
+			key := tok.(string)
+			var v interface{}
+			if err := d.Decode(&v); err != nil {
+				return nil, nil, err
+			}
+			i, ok := index[key]
+			if !ok {
+				i = len(headers)
+				index[key] = i
+				headers = append(headers, key)
+			}
+			for len(row) <= i {
+				row = append(row, nil)
+			}
+			if v != nil {
+				row[i] = jsonString(v)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return headers, rows, nil
+}
+
+// jsonString converts a decoded JSON value to a cell value.
+func jsonString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// number parses s as a finite number.
+func number(s string) (float64, bool) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return f, err == nil && !math.IsInf(f, 0) && !math.IsNaN(f)
+}
+
+// numberKey is the sort key of number columns. Empty fields sort first.
+func numberKey(s string) interface{} {
+	if f, ok := number(s); ok {
+		return f
+	}
+	return nil
+}
+
+// numericColumns returns the columns of rows holding numbers, ignoring empty and NULL fields.
+func numericColumns(n int, rows [][]interface{}) []int {
+	var cols []int
+	for i := 0; i < n; i++ {
+		found, numeric := false, true
+		for _, r := range rows {
+			if i >= len(r) {
+				continue
+			}
+			if s, ok := r[i].(string); ok && s != "" {
+				_, ok := number(s)
+				found, numeric = true, numeric && ok
+			}
+		}
+		if found && numeric {
+			cols = append(cols, i)
+		}
+	}
+	return cols
+}
+
+// column returns the index of the column named by a header or an index.
+func column(headers []string, name string) (int, error) {
+	for i, h := range headers {
+		if h == name {
+			return i, nil
+		}
+	}
+	i, err := strconv.Atoi(name)
+	if err != nil || i < 0 || i >= len(headers) {
+		return 0, fmt.Errorf("unknown column %q", name)
+	}
+	return i, nil
+}
+
+func columns(headers []string, list string) ([]int, error) {
+	var cols []int
+	for _, name := range strings.Split(list, ",") {
+		i, err := column(headers, strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, i)
+	}
+	return cols, nil
+}
+
+// parseFilter returns a function reporting whether a row matches all conditions of filter.
+func parseFilter(headers []string, filter string) (func([]interface{}) bool, error) {
+	type condition struct {
+		col   int
+		value string
+	}
+	var conds []condition
+	if filter != "" {
+		for _, c := range strings.Split(filter, ",") {
+			kv := strings.SplitN(c, "=", 2)
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("invalid filter %q", c)
+			}
+			i, err := column(headers, strings.TrimSpace(kv[0]))
+			if err != nil {
+				return nil, err
+			}
+			conds = append(conds, condition{col: i, value: kv[1]})
+		}
+	}
+	return func(row []interface{}) bool {
+		for _, c := range conds {
+			if c.col >= len(row) || row[c.col] != c.value {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
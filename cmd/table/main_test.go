@@ -0,0 +1,42 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jayloop/table"
+)
+
+func TestRunCSV(t *testing.T) {
+	for _, c := range []struct {
+		sortBy, filter string
+		want           string
+	}{
+		{"requests", "", "host   region   cpu  requests\n" +
+			"db-1   eu                  80\n" +
+			"web-1  eu      12.5       900\n" +
+			"web-2  us         3     10000\n"},
+		{"cpu", "", "host   region   cpu  requests\n" +
+			"db-1   eu                  80\n" +
+			"web-2  us         3     10000\n" +
+			"web-1  eu      12.5       900\n"},
+		{"host", "region=eu", "host   region   cpu  requests\n" +
+			"db-1   eu                  80\n" +
+			"web-1  eu      12.5       900\n"},
+	} {
+		f, err := os.Open("testdata/hosts.csv")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var b strings.Builder
+		err = run(f, &b, "", c.sortBy, c.filter, 0, "", "", table.Profile{})
+		f.Close()
+		if err != nil {
+			t.Fatalf("sort %q filter %q: %v", c.sortBy, c.filter, err)
+		}
+		if got := b.String(); got != c.want {
+			t.Errorf("sort %q filter %q: got\n%s\nwant\n%s", c.sortBy, c.filter, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package table
+
+import (
+	"io"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// ColorLevel is the level of color support used when printing.
+type ColorLevel int
+
+// Color levels
+const (
+	// ColorDefault uses the package level set by SetColorLevel, or else the level given by the
+	// environment, which is ColorNone when NO_COLOR is set or the output is not a terminal, and
+	// else the level of the terminal given by COLORTERM and TERM.
+	ColorDefault ColorLevel = iota
+	// ColorNone prints no escape codes at all.
+	ColorNone
+	// ColorBasic allows the 16 basic ANSI colors and decorations.
+	ColorBasic
+	// Color256 allows the 256 color palette.
+	Color256
+	// ColorTrue allows 24-bit colors.
+	ColorTrue
+)
+
+var (
+	colorLevel ColorLevel
+	forceColor bool
+)
+
+// SetColorLevel sets the color level of all tables not having their own level set by Table.ColorLevel.
+// It overrides the level given by the environment; use ColorDefault to go back to it.
+func SetColorLevel(l ColorLevel) {
+	mu.Lock()
+	colorLevel = l
+	mu.Unlock()
+}
+
+// ForceColor enables or disables colors for all tables printed with the level given by the
+// environment, even when NO_COLOR is set or the output is not a terminal, like a pipe or a file.
+func ForceColor(enabled bool) {
+	mu.Lock()
+	forceColor = enabled
+	mu.Unlock()
+}
+
+// ColorLevel sets the color level of the table, overriding the package level.
+func (t *Table) ColorLevel(l ColorLevel) {
+	t.colorLevel = l
+}
+
+// color returns the color level in effect for the table printed to out.
+func (t *Table) color(out io.Writer) ColorLevel {
+	if t.colorLevel != ColorDefault {
+		return t.colorLevel
+	}
+	mu.RLock()
+	l, force := colorLevel, forceColor
+	mu.RUnlock()
+	if l != ColorDefault {
+		return l
+	}
+	return envColorLevel(out, force)
+}
+
+// envColorLevel returns the color level given by the FORCE_COLOR, CLICOLOR_FORCE, NO_COLOR and
+// CLICOLOR environment variables, and by whether out is a terminal unless force is set.
+// Terminals and forced colors get the level of termColorLevel, at least ColorBasic when forced.
+func envColorLevel(out io.Writer, force bool) ColorLevel {
+	if v, ok := os.LookupEnv("FORCE_COLOR"); ok {
+		switch strings.ToLower(v) {
+		case "0", "false":
+			return ColorNone
+		case "", "1", "true":
+			return ColorBasic
+		case "2":
+			return Color256
+		default:
+			return ColorTrue
+		}
+	}
+	if v := os.Getenv("CLICOLOR_FORCE"); force || v != "" && v != "0" {
+		if l := termColorLevel(); l > ColorBasic {
+			return l
+		}
+		return ColorBasic
+	}
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("CLICOLOR") == "0" {
+		return ColorNone
+	}
+	if f, ok := out.(*os.File); !ok || !isTerminal(f) {
+		return ColorNone
+	}
+	return termColorLevel()
+}
+
+// termColorLevel returns the color level of the terminal: ColorTrue when COLORTERM is truecolor
+// or 24bit, Color256 for TERM ending in 256color, ColorNone for the dumb terminal and else
+// ColorBasic. Windows consoles, which don't set TERM, support 24-bit colors.
+func termColorLevel() ColorLevel {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ColorTrue
+	}
+	term := strings.ToLower(os.Getenv("TERM"))
+	switch {
+	case term == "dumb":
+		return ColorNone
+	case strings.HasSuffix(term, "256color"):
+		return Color256
+	case term == "" && runtime.GOOS == "windows":
+		return ColorTrue
+	}
+	return ColorBasic
+}
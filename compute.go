@@ -0,0 +1,300 @@
+package table
+
+import (
+	"math"
+	"sort"
+	"strconv"
+)
+
+// A computed column has its values derived from the other columns when the table is printed or sorted.
+type computed struct {
+	// fn returns the column values for the given rows, in print order.
+	fn func(rows []row) []interface{}
+	// format converts a value to a string, printing floats with p digits.
+	// If nil, values are converted as if passed to Row.
+	format func(v interface{}, p int) string
+}
+
+// addComputed adds a computed column with the given header, derived from column col, and returns its index.
+func (t *Table) addComputed(col int, header string, c computed) int {
+	if !t.validCol(col) {
+		c.fn = noValues
+	}
+	i := t.addColumn(header)
+	t.computed[i] = c
+	return i
+}
+
+// noValues computes an empty column, for columns derived from a column out of range.
+func noValues(rows []row) []interface{} {
+	return make([]interface{}, len(rows))
+}
+
+// compute updates the values of all computed columns, derived from the printed rows in print
+// order. Rows hidden by HideZeroRows and the removed rows appended by TrackRows are left empty.
+func (t *Table) compute() {
+	if len(t.computed) == 0 {
+		return
+	}
+	var printed []int
+	for j := range t.rows {
+		r := &t.rows[j]
+		if !r.removed && !(t.hideZero && !r.blank && t.zero(r)) {
+			printed = append(printed, j)
+		}
+	}
+	rows := make([]row, len(printed))
+	for i := 0; i < t.columns; i++ {
+		c, ok := t.computed[i]
+		if !ok {
+			continue
+		}
+		// copied for each column, as columns may be derived from the ones computed before
+		for n, j := range printed {
+			rows[n] = t.rows[j]
+		}
+		values := make([]interface{}, len(t.rows))
+		for n, v := range c.fn(rows) {
+			values[printed[n]] = v
+		}
+		p := t.precision[i]
+		if p == 0 {
+			p = 2
+		}
+		t.kinds[i] = kindUnknown
+		for j := range t.rows {
+			r := &t.rows[j]
+			if r.blank {
+				values[j] = nil
+			}
+			if k := kindOf(values[j]); k > t.kinds[i] {
+				t.kinds[i] = k
+			}
+			s := ""
+			if c.format != nil {
+				s = c.format(values[j], p)
+			} else {
+				s = formatValue(values[j], p)
+			}
+			r.setCell(t.columns, i, values[j], s)
+		}
+	}
+}
+
+// toFloat converts a numeric value, or a string holding a number, to a float64.
+func toFloat(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	case *string:
+		f, err := strconv.ParseFloat(*v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// toInt converts an integer value to an int64, if it fits.
+func toInt(v interface{}) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint:
+		return int64(v), uint64(v) <= math.MaxInt64
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		return int64(v), v <= math.MaxInt64
+	}
+	return 0, false
+}
+
+// PercentOfTotal adds a column with the given header showing each row's share of the total of column col,
+// printed as a percentage. Rows with non-numeric values are left empty.
+// It returns the index of the new column, which can be used to set its precision or format.
+func (t *Table) PercentOfTotal(col int, header string) int {
+	return t.addComputed(col, header, computed{
+		fn: func(rows []row) []interface{} {
+			var total float64
+			for j := range rows {
+				if f, ok := toFloat(rows[j].value(col)); ok {
+					total += f
+				}
+			}
+			values := make([]interface{}, len(rows))
+			for j := range rows {
+				if f, ok := toFloat(rows[j].value(col)); ok && total != 0 {
+					values[j] = f / total * 100
+				}
+			}
+			return values
+		},
+		format: func(v interface{}, p int) string {
+			if v == nil {
+				return ""
+			}
+			return formatValue(v, p) + "%"
+		},
+	})
+}
+
+// Delta adds a column with the given header showing the change of column col versus the previous printed row,
+// either as an absolute difference or, if percent is set, as a percentage of the previous value.
+// Increases are prefixed with a plus sign; use FormatSign on the returned column index to color them.
+func (t *Table) Delta(col int, header string, percent bool) int {
+	return t.addComputed(col, header, computed{
+		fn: func(rows []row) []interface{} {
+			values := make([]interface{}, len(rows))
+			for j := 1; j < len(rows); j++ {
+				prev, cur := rows[j-1].value(col), rows[j].value(col)
+				if a, ok := toInt(prev); ok && !percent {
+					if b, ok := toInt(cur); ok {
+						values[j] = b - a
+						continue
+					}
+				}
+				a, ok1 := toFloat(prev)
+				b, ok2 := toFloat(cur)
+				switch {
+				case !ok1 || !ok2:
+				case !percent:
+					values[j] = b - a
+				case a != 0:
+					values[j] = (b - a) / a * 100
+				}
+			}
+			return values
+		},
+		format: func(v interface{}, p int) string {
+			if v == nil {
+				return ""
+			}
+			s := formatValue(v, p)
+			if f, _ := toFloat(v); f > 0 {
+				s = "+" + s
+			}
+			if percent {
+				s += "%"
+			}
+			return s
+		},
+	})
+}
+
+// CumulativeSum adds a column with the given header showing the running total of column col,
+// summed in print order. Non-numeric values are skipped.
+func (t *Table) CumulativeSum(col int, header string) int {
+	return t.addComputed(col, header, computed{
+		fn: func(rows []row) []interface{} {
+			values := make([]interface{}, len(rows))
+			var (
+				n     int64
+				f     float64
+				float bool
+			)
+			for j := range rows {
+				v := rows[j].value(col)
+				if i, ok := toInt(v); ok {
+					n += i
+				} else if x, ok := toFloat(v); ok {
+					f += x
+					float = true
+				}
+				if float {
+					values[j] = float64(n) + f
+				} else {
+					values[j] = n
+				}
+			}
+			return values
+		},
+	})
+}
+
+// Ties controls how Rank numbers rows with equal values.
+type Ties int
+
+// Tie handling modes
+const (
+	// TiesCompetition gives equal values the same rank and skips the following ranks (1, 2, 2, 4).
+	TiesCompetition Ties = iota
+	// TiesDense gives equal values the same rank without gaps (1, 2, 2, 3).
+	TiesDense
+	// TiesOrdinal gives every row a distinct rank in insertion order (1, 2, 3, 4).
+	TiesOrdinal
+)
+
+// Rank adds a column with the given header ranking the rows by the numeric value of column col,
+// the highest value ranking first. The rank does not depend on the order the rows are printed in.
+// Rows with non-numeric values are left unranked.
+func (t *Table) Rank(col int, header string, ties Ties) int {
+	return t.addComputed(col, header, computed{
+		fn: func(rows []row) []interface{} {
+			type entry struct {
+				j, index int
+				f        float64
+			}
+			var entries []entry
+			for j := range rows {
+				if f, ok := toFloat(rows[j].value(col)); ok {
+					entries = append(entries, entry{j: j, index: rows[j].index, f: f})
+				}
+			}
+			sort.Slice(entries, func(a, b int) bool {
+				if entries[a].f != entries[b].f {
+					return entries[a].f > entries[b].f
+				}
+				return entries[a].index < entries[b].index
+			})
+			values := make([]interface{}, len(rows))
+			rank := 0
+			for k, e := range entries {
+				switch {
+				case k > 0 && ties != TiesOrdinal && e.f == entries[k-1].f:
+				case ties == TiesDense:
+					rank++
+				default:
+					rank = k + 1
+				}
+				values[e.j] = rank
+			}
+			return values
+		},
+	})
+}
@@ -0,0 +1,108 @@
+package table
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVOptions holds the options of PrintCSV.
+type CSVOptions struct {
+	// Delimiter separates the fields of a record. The default is a comma.
+	Delimiter rune
+	// NoHeader omits the header record.
+	NoHeader bool
+}
+
+// PrintCSV writes the table as comma separated values, quoted as by the encoding/csv package.
+// Numbers, booleans and times passed to Row are written in full, like 1536, true and
+// 2006-01-02T15:04:05Z, so that spreadsheets read them as such, regardless of Precision or AutoFormat.
+// Other values are written as printed by Print, but without formatting, truncation or merging.
+// Blank rows added by BlankRow are omitted. Records end with "\r\n" if set by LineEnding.
+func (t *Table) PrintCSV(out io.Writer, o CSVOptions) error {
+	v := t.baseView(false)
+	w := csv.NewWriter(out)
+	if o.Delimiter != 0 {
+		w.Comma = o.Delimiter
+	}
+	w.UseCRLF = t.eol == "\r\n"
+	if !o.NoHeader {
+		if err := w.Write(stripAll(v.headers)); err != nil {
+			return err
+		}
+	}
+	for j, cells := range v.cells {
+		r := &t.rows[v.rows[j]]
+		if r.blank {
+			continue
+		}
+		record := stripAll(cells)
+		for k := range record {
+			if raw, ok := t.nativeValue(r, v.src[k]); ok {
+				record[k] = csvValue(raw)
+			}
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// csvValue formats a native value for PrintCSV.
+func csvValue(v interface{}) string {
+	switch v := v.(type) {
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v)
+}
+
+// tsvReplacer replaces the characters that would split a TSV field or record.
+var tsvReplacer = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+// PrintTSV writes the table as tab separated values, for piping into tools like cut or awk:
+// one line per row, with a single tab between values and no padding, escape codes or quoting.
+// Tabs and line breaks in values are replaced by spaces. As with PrintCSV, values are not
+// truncated or merged, and blank rows are omitted.
+func (t *Table) PrintTSV(out io.Writer) error {
+	v := t.baseView(false)
+	p := t.printer(out)
+	p.level = ColorNone
+	p.clip = 0
+	writeLine := func(values []string) {
+		for k, s := range values {
+			if k > 0 {
+				p.writeString("\t")
+			}
+			p.writeString(tsvReplacer.Replace(s))
+		}
+		p.endLine()
+	}
+	writeLine(v.headers)
+	for j, cells := range v.cells {
+		if !t.rows[v.rows[j]].blank {
+			writeLine(cells)
+		}
+	}
+	return p.close()
+}
+
+// stripAll returns values with any escape codes removed.
+func stripAll(values []string) []string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = stripANSI(v)
+	}
+	return s
+}
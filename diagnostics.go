@@ -0,0 +1,53 @@
+package table
+
+// Diagnostics describes how a table was printed, to help tune its MaxWidth and TotalMaxWidth
+// settings. Columns are identified by their index, as passed to MaxWidth.
+type Diagnostics struct {
+	// Layout is the layout of the printed table.
+	Layout Layout
+	// Truncated holds the number of truncated cells of each column with any.
+	Truncated map[int]int
+	// Wrapped holds the number of cells wrapped onto continuation lines of each column with any.
+	Wrapped map[int]int
+	// Overflow is the number of cells wider than their column, truncated or wrapped.
+	Overflow int
+	// Headers lists the columns with an abbreviated or truncated header.
+	Headers []int
+}
+
+// OnDiagnostics sets a function called with the diagnostics of the table after each Print.
+func (t *Table) OnDiagnostics(fn func(d Diagnostics)) {
+	t.onDiagnostics = fn
+}
+
+// diagnose computes the diagnostics of printed view v.
+func (t *Table) diagnose(v *view) Diagnostics {
+	d := Diagnostics{
+		Layout:    t.layout(v),
+		Truncated: make(map[int]int),
+		Wrapped:   make(map[int]int),
+	}
+	for n, c := range v.parts() {
+		for k, i := range c.src {
+			// the frozen columns repeated by FreezeColumns are only counted once
+			if i == indexCol || n > 0 && k < t.frozen {
+				continue
+			}
+			if c.headers[k] != t.headers[i] || stringWidth(t.formatHeaderValue(c.headers[k])) > c.widths[k] {
+				d.Headers = append(d.Headers, i)
+			}
+			for j := range c.out {
+				if _, ok := t.raw(c, j, k); ok || linesLen(c.out[j][k]) <= c.widths[k] {
+					continue
+				}
+				d.Overflow++
+				if t.wrap[i] {
+					d.Wrapped[i]++
+				} else {
+					d.Truncated[i]++
+				}
+			}
+		}
+	}
+	return d
+}
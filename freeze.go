@@ -0,0 +1,92 @@
+package table
+
+// FreezeColumns prints tables wider than set by TotalMaxWidth, or than the terminal with AutoFit,
+// in chunks of columns printed one below the other and separated by a blank line, instead of
+// shrinking all columns to fit. Each chunk starts with the first n printed columns, like the
+// names identifying the rows, followed by as many of the other columns as fit. Columns too wide
+// for a chunk of their own are still truncated. Only Print and Layout split tables: PrintHeader
+// and PrintRows, which print to the same widths line by line, shrink the columns as usual.
+// Use 0, the default, to never split tables.
+func (t *Table) FreezeColumns(n int) {
+	t.frozen = n
+}
+
+// chunk splits view v into views of the frozen columns followed by as many of the other columns
+// as fit in limit characters, each shrunk to fit if still too wide.
+func (t *Table) chunk(v *view, limit int) []*view {
+	var chunks []*view
+	frozen := make([]int, t.frozen)
+	for k := range frozen {
+		frozen[k] = k
+	}
+	cols := frozen
+	for k := t.frozen; k < len(v.src); k++ {
+		next := append(cols[:len(cols):len(cols)], k)
+		if len(cols) > t.frozen && t.width(v.part(next)) > limit {
+			chunks = append(chunks, t.fitChunk(v.part(cols), limit))
+			next = append(frozen[:t.frozen:t.frozen], k)
+		}
+		cols = next
+	}
+	return append(chunks, t.fitChunk(v.part(cols), limit))
+}
+
+// fitChunk abbreviates the headers and shrinks the columns of chunk c to fit in limit characters.
+func (t *Table) fitChunk(c *view, limit int) *view {
+	t.abbreviate(c, limit)
+	t.fit(c, limit)
+	return c
+}
+
+// part returns a view of the printed columns cols of v, in that order, without notes.
+func (v *view) part(cols []int) *view {
+	p := *v
+	p.notes, p.legend, p.chunks = nil, nil, nil
+	p.src = make([]int, len(cols))
+	p.headers = make([]string, len(cols))
+	p.widths = make([]int, len(cols))
+	p.align = make([]Alignment, len(cols))
+	for n, k := range cols {
+		p.src[n], p.headers[n], p.widths[n], p.align[n] = v.src[k], v.headers[k], v.widths[k], v.align[k]
+	}
+	pick := func(rows [][]string) [][]string {
+		if rows == nil {
+			return nil
+		}
+		picked := make([][]string, len(rows))
+		for j, cells := range rows {
+			if cells == nil {
+				continue
+			}
+			picked[j] = make([]string, len(cols))
+			for n, k := range cols {
+				picked[j][n] = cells[k]
+			}
+		}
+		return picked
+	}
+	p.cells, p.out = pick(v.cells), pick(v.out)
+	p.footers, p.footerOut = pick(v.footers), pick(v.footerOut)
+	p.marks = pick(v.marks)
+	if v.spans != nil {
+		p.spans = make([][]bool, len(v.spans))
+		for j, spans := range v.spans {
+			if spans == nil {
+				continue
+			}
+			p.spans[j] = make([]bool, len(cols))
+			for n, k := range cols {
+				p.spans[j][n] = spans[k]
+			}
+		}
+	}
+	return &p
+}
+
+// parts returns the chunks v is printed in, or v itself if it is not split.
+func (v *view) parts() []*view {
+	if v.chunks == nil {
+		return []*view{v}
+	}
+	return v.chunks
+}
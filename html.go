@@ -0,0 +1,205 @@
+package table
+
+import (
+	"html"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// HTMLOptions holds the options of PrintHTML.
+type HTMLOptions struct {
+	// Classes maps column indexes to CSS classes set on their header and data cells.
+	Classes map[int]string
+}
+
+// cssColors maps ANSI colors to CSS colors.
+var cssColors = map[CodeANSI]string{
+	Black: "black", Red: "maroon", Green: "green", Yellow: "olive",
+	Blue: "navy", Magenta: "purple", Cyan: "teal", White: "silver",
+	HiBlack: "gray", HiRed: "red", HiGreen: "lime", HiYellow: "yellow",
+	HiBlue: "blue", HiMagenta: "fuchsia", HiCyan: "aqua", HiWhite: "white",
+}
+
+// cssDecorations maps ANSI decorations to CSS declarations.
+var cssDecorations = map[CodeANSI]string{
+	Bold:       "font-weight:bold",
+	Faint:      "opacity:0.5",
+	Italic:     "font-style:italic",
+	Underline:  "text-decoration:underline",
+	CrossedOut: "text-decoration:line-through",
+}
+
+// css returns the CSS declarations of s.
+func (s Style) css() []string {
+	var d []string
+	if c, ok := cssColors[s.Fg]; ok {
+		d = append(d, "color:"+c)
+	}
+	if c, ok := cssColors[s.Bg]; ok {
+		d = append(d, "background-color:"+c)
+	}
+	for _, a := range s.Attrs {
+		if c, ok := cssDecorations[a]; ok {
+			d = append(d, c)
+		}
+	}
+	return d
+}
+
+// PrintHTML prints the table as an HTML table with a thead, a tbody and, for footer rows, a tfoot,
+// so that the same table can be shown on a web page. Values are escaped and printed without formatting or truncation.
+// Styles set by StyleHeader, StyleCols and StyleRows are translated into inline CSS, but format
+// functions are ignored. Row and cell metadata is added as data attributes, like data-url, and
+// annotations set by Annotate as title attributes, which browsers show as tooltips.
+// Notes, like the caption of HideConstant, are printed as the table caption.
+func (t *Table) PrintHTML(out io.Writer, o HTMLOptions) error {
+	v := t.view()
+	p := t.printer(out)
+	p.level = ColorNone
+	p.clip = 0
+	p.writeString("<table>")
+	p.endLine()
+	if len(v.notes) > 0 {
+		p.writeString("<caption>" + html.EscapeString(strings.Join(v.notes, "; ")) + "</caption>")
+		p.endLine()
+	}
+	p.writeString("<thead>")
+	p.endLine()
+	var b strings.Builder
+	b.WriteString("<tr>")
+	for k, h := range v.headers {
+		var style []string
+		if t.styleHeader != nil {
+			style = t.styleHeader.css()
+		}
+		b.WriteString("<th")
+		t.writeAttrs(&b, v, k, o, style, nil)
+		b.WriteString(">" + html.EscapeString(h) + "</th>")
+	}
+	b.WriteString("</tr>")
+	p.writeString(b.String())
+	p.endLine()
+	p.writeString("</thead>")
+	p.endLine()
+	p.writeString("<tbody>")
+	p.endLine()
+	for j, cells := range v.cells {
+		r := &t.rows[v.rows[j]]
+		b.Reset()
+		b.WriteString("<tr")
+		if s := t.styleRows[v.rows[j]]; s != nil {
+			writeAttr(&b, "style", strings.Join(s.css(), ";"))
+		}
+		writeMeta(&b, r.meta)
+		b.WriteString(">")
+		for k, c := range cells {
+			if v.spanned(j, k) {
+				continue
+			}
+			var style []string
+			var meta Meta
+			if i := v.src[k]; i != indexCol {
+				if s := t.styleCols[i]; s != nil {
+					style = s.css()
+				}
+				meta = r.cellMeta[i]
+			}
+			b.WriteString("<td")
+			t.writeAttrs(&b, v, k, o, style, meta)
+			if n := v.span(j, k); n > 1 {
+				writeAttr(&b, "rowspan", strconv.Itoa(n))
+			}
+			if i := v.src[k]; i != indexCol && r.annotations[i] != "" {
+				writeAttr(&b, "title", r.annotations[i])
+			}
+			c = html.EscapeString(strings.TrimSpace(c))
+			if url := t.link(v.rows[j], v.src[k]); url != "" {
+				c = `<a href="` + html.EscapeString(url) + `">` + c + "</a>"
+			}
+			b.WriteString(">" + c + "</td>")
+		}
+		b.WriteString("</tr>")
+		p.writeString(b.String())
+		p.endLine()
+	}
+	p.writeString("</tbody>")
+	p.endLine()
+	if len(v.footers) > 0 {
+		p.writeString("<tfoot>")
+		p.endLine()
+		for _, cells := range v.footers {
+			b.Reset()
+			b.WriteString("<tr>")
+			for k, c := range cells {
+				var style []string
+				if i := v.src[k]; i != indexCol && t.styleCols[i] != nil {
+					style = t.styleCols[i].css()
+				}
+				b.WriteString("<td")
+				t.writeAttrs(&b, v, k, o, style, nil)
+				b.WriteString(">" + html.EscapeString(strings.TrimSpace(c)) + "</td>")
+			}
+			b.WriteString("</tr>")
+			p.writeString(b.String())
+			p.endLine()
+		}
+		p.writeString("</tfoot>")
+		p.endLine()
+	}
+	p.writeString("</table>")
+	p.endLine()
+	return p.close()
+}
+
+// writeAttrs writes the attributes of a cell of printed column k.
+func (t *Table) writeAttrs(b *strings.Builder, v *view, k int, o HTMLOptions, style []string, meta Meta) {
+	if i := v.src[k]; i != indexCol && o.Classes[i] != "" {
+		writeAttr(b, "class", o.Classes[i])
+	}
+	switch v.align[k] {
+	case Right:
+		style = append([]string{"text-align:right"}, style...)
+	case Center:
+		style = append([]string{"text-align:center"}, style...)
+	}
+	if len(style) > 0 {
+		writeAttr(b, "style", strings.Join(style, ";"))
+	}
+	writeMeta(b, meta)
+}
+
+// writeMeta writes metadata as data attributes, sorted by key. Keys that aren't valid in
+// attribute names are left out.
+func writeMeta(b *strings.Builder, m Meta) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		if validDataKey(k) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		writeAttr(b, "data-"+strings.ToLower(k), m[k])
+	}
+}
+
+// validDataKey reports whether k can name a data attribute: letters, digits, "-", "_" and ".".
+func validDataKey(k string) bool {
+	if k == "" {
+		return false
+	}
+	for _, c := range k {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func writeAttr(b *strings.Builder, name, value string) {
+	b.WriteString(" " + name + `="` + html.EscapeString(value) + `"`)
+}
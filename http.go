@@ -0,0 +1,113 @@
+package table
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RenderOptions holds the options of each renderer, for tables rendered in a format chosen at
+// render time, like by HandlerWith.
+type RenderOptions struct {
+	Markdown MarkdownOptions
+	HTML     HTMLOptions
+	CSV      CSVOptions
+}
+
+// renderers maps the media types served by Handler to the functions rendering a table.
+var renderers = map[string]func(t *Table, w io.Writer, o RenderOptions) error{
+	"text/plain": func(t *Table, w io.Writer, o RenderOptions) error {
+		return t.Print(w)
+	},
+	"text/markdown": func(t *Table, w io.Writer, o RenderOptions) error {
+		return t.PrintMarkdown(w, o.Markdown)
+	},
+	"text/csv": func(t *Table, w io.Writer, o RenderOptions) error {
+		return t.PrintCSV(w, o.CSV)
+	},
+	"text/html": func(t *Table, w io.Writer, o RenderOptions) error {
+		return t.PrintHTML(w, o.HTML)
+	},
+	"application/json": func(t *Table, w io.Writer, o RenderOptions) error {
+		return t.PrintJSON(w)
+	},
+	"text/tab-separated-values": func(t *Table, w io.Writer, o RenderOptions) error {
+		return t.PrintTSV(w)
+	},
+}
+
+// mediaTypes lists the media types of renderers in order of preference.
+var mediaTypes = []string{"text/plain", "text/markdown", "text/csv", "text/html", "application/json", "text/tab-separated-values"}
+
+// Handler returns an http.Handler serving the table in the format chosen from the Accept header
+// of each request, like text/plain, text/html or application/json. Requests accepting none of the formats get a 406 response.
+// The table is printed by one request at a time, and must not be modified while the handler is in use.
+// Markdown is served with alignment markers, see HandlerWith to set the options of each format.
+func Handler(t *Table) http.Handler {
+	return HandlerWith(t, RenderOptions{Markdown: MarkdownOptions{AlignmentMarkers: true}})
+}
+
+// HandlerWith returns an http.Handler serving the table like Handler, rendered with options o.
+func HandlerWith(t *Table, o RenderOptions) http.Handler {
+	var mu sync.Mutex
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		typ := negotiate(r.Header.Get("Accept"))
+		if typ == "" {
+			http.Error(w, http.StatusText(http.StatusNotAcceptable), http.StatusNotAcceptable)
+			return
+		}
+		mu.Lock()
+		b := appendWriter{}
+		err := renderers[typ](t, &b, o)
+		mu.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", typ+"; charset=utf-8")
+		w.Write(b.b)
+	})
+}
+
+// negotiate returns the preferred media type of renderers matching an Accept header,
+// or an empty string if none do.
+func negotiate(accept string) string {
+	if strings.TrimSpace(accept) == "" {
+		return mediaTypes[0]
+	}
+	type option struct {
+		typ string
+		q   float64
+	}
+	var options []option
+	for _, part := range strings.Split(accept, ",") {
+		typ, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if s, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(s, 64); err != nil {
+				continue
+			}
+		}
+		if q > 0 {
+			options = append(options, option{typ: typ, q: q})
+		}
+	}
+	sort.SliceStable(options, func(i, j int) bool {
+		return options[i].q > options[j].q
+	})
+	for _, o := range options {
+		for _, typ := range mediaTypes {
+			if o.typ == typ || o.typ == "*/*" || (strings.HasSuffix(o.typ, "/*") && strings.HasPrefix(typ, o.typ[:len(o.typ)-1])) {
+				return typ
+			}
+		}
+	}
+	return ""
+}
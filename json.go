@@ -0,0 +1,86 @@
+package table
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// MarshalJSON returns the table as a JSON array of objects, with one object per row keyed by
+// the headers, in column order. Numbers, booleans and times passed to Row are exported as JSON
+// values, NULL values as null and other values as printed strings. Blank rows added by BlankRow are omitted.
+func (t *Table) MarshalJSON() ([]byte, error) {
+	v := t.baseView(false)
+	var b bytes.Buffer
+	b.WriteByte('[')
+	first := true
+	for j, cells := range v.cells {
+		r := &t.rows[v.rows[j]]
+		if r.blank {
+			continue
+		}
+		if !first {
+			b.WriteByte(',')
+		}
+		first = false
+		b.WriteByte('{')
+		for k, c := range cells {
+			if k > 0 {
+				b.WriteByte(',')
+			}
+			key, _ := json.Marshal(v.headers[k])
+			b.Write(key)
+			b.WriteByte(':')
+			b.Write(t.jsonValue(r, v.src[k], c))
+		}
+		b.WriteByte('}')
+	}
+	b.WriteByte(']')
+	return b.Bytes(), nil
+}
+
+// jsonValue returns the JSON encoding of the value of column i in row r, printed as s.
+func (t *Table) jsonValue(r *row, i int, s string) []byte {
+	if i != indexCol && r.null(i) {
+		return []byte("null")
+	}
+	if raw, ok := t.nativeValue(r, i); ok {
+		// floats like NaN can't be encoded, and fall back to the printed value
+		if b, err := json.Marshal(raw); err == nil {
+			return b
+		}
+	}
+	b, _ := json.Marshal(s)
+	return b
+}
+
+// nativeValue returns the value of column i in row r as passed to Row, if it is a number,
+// a boolean or a time, so that exports can keep its type. Anonymized values are never returned.
+func (t *Table) nativeValue(r *row, i int) (interface{}, bool) {
+	if i == indexCol {
+		return r.index, true
+	}
+	if _, ok := t.anonymize[i]; ok {
+		return nil, false
+	}
+	switch v := r.value(i).(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, bool, time.Time:
+		return v, true
+	}
+	return nil, false
+}
+
+// PrintJSON writes the table as returned by MarshalJSON, followed by a line ending.
+func (t *Table) PrintJSON(out io.Writer) error {
+	b, err := t.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	p := t.printer(out)
+	p.level = ColorNone
+	p.clip = 0
+	p.writeString(string(b))
+	p.endLine()
+	return p.close()
+}
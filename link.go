@@ -0,0 +1,36 @@
+package table
+
+import "strings"
+
+// Link returns a formatting function making values hyperlinks to url in terminals supporting
+// the OSC 8 escape sequence, while other terminals show the value only. The printed width of
+// a value is unchanged. Links are removed with the other escape codes when colors are disabled.
+func Link(url string) FormatFunc {
+	return func(s string) string {
+		return "\x1b]8;;" + url + "\x1b\\" + s + "\x1b]8;;\x1b\\"
+	}
+}
+
+// SetLink makes the cell of column col in the row at index row a hyperlink to url, as by Link.
+// Use row index -1 to denote the last row. PrintHTML and PrintMarkdown print the cell as a link
+// too. The link stays with the row when the table is sorted.
+func (t *Table) SetLink(row, col int, url string) {
+	r := t.rowAt(row)
+	if r == nil || !t.validCol(col) {
+		return
+	}
+	r.links = withString(r.links, col, url)
+}
+
+// link returns the url set by SetLink for column i of row j, if any.
+func (t *Table) link(j, i int) string {
+	if i == indexCol || j < 0 || j >= len(t.rows) {
+		return ""
+	}
+	return t.rows[j].links[i]
+}
+
+// markdownLink returns label as a Markdown link to url.
+func markdownLink(label, url string) string {
+	return "[" + label + "](" + strings.NewReplacer(" ", "%20", ")", "%29").Replace(url) + ")"
+}
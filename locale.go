@@ -0,0 +1,102 @@
+package table
+
+import (
+	"strings"
+	"time"
+)
+
+// A Locale describes how numbers and dates are written for an audience.
+type Locale struct {
+	// Group separates groups of three digits in the integer part of numbers, or none if empty.
+	Group string
+	// Decimal separates the integer and fraction parts of numbers, or "." if empty.
+	Decimal string
+	// DateLayout is the layout of time.Time values as used by time.Format, or the
+	// default format of the fmt package if empty.
+	DateLayout string
+}
+
+// Common locales.
+var (
+	LocaleUS  = Locale{Group: ",", Decimal: ".", DateLayout: "01/02/2006"}
+	LocaleUK  = Locale{Group: ",", Decimal: ".", DateLayout: "02/01/2006"}
+	LocaleDE  = Locale{Group: ".", Decimal: ",", DateLayout: "02.01.2006"}
+	LocaleFR  = Locale{Group: " ", Decimal: ",", DateLayout: "02/01/2006"}
+	LocaleISO = Locale{Decimal: ".", DateLayout: "2006-01-02"}
+)
+
+// UseLocale sets the listed columns to print numbers and dates as written in locale l, so that
+// columns meant for different audiences can be mixed in a table. It replaces the conversions set
+// by AutoFormat for the columns. It must be set before adding the rows.
+func (t *Table) UseLocale(l Locale, cols ...int) {
+	for _, col := range cols {
+		if t.validCol(col) {
+			t.convert[col] = l.convert
+			t.locales[col] = l
+			t.relative[col] = false
+		}
+	}
+}
+
+// convert converts numbers and time.Time values as written in the locale.
+func (l Locale) convert(v interface{}, p int) (string, bool) {
+	switch v := v.(type) {
+	case int, int32, int64, uint32, uint64, float32, float64:
+		return l.number(formatValue(v, p)), true
+	case time.Time:
+		if l.DateLayout == "" {
+			return "", false
+		}
+		return v.Format(l.DateLayout), true
+	}
+	return "", false
+}
+
+// number rewrites number s, as printed by formatValue, with the separators of the locale.
+func (l Locale) number(s string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, frac = s[:i], s[i+1:]
+	}
+	// values like "+Inf" and "1e+21" have no digits to group
+	if strings.TrimLeft(whole, "0123456789") != "" {
+		return sign + s
+	}
+	var b strings.Builder
+	b.WriteString(sign)
+	for i := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(l.Group)
+		}
+		b.WriteByte(whole[i])
+	}
+	if frac != "" {
+		if l.Decimal == "" {
+			b.WriteByte('.')
+		} else {
+			b.WriteString(l.Decimal)
+		}
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+// delocalize rewrites number s, as written in the locale, as printed by formatValue, so that it
+// can be parsed. Other values, like dates, are returned unchanged.
+func (l Locale) delocalize(s string) string {
+	d := s
+	if l.Group != "" {
+		d = strings.ReplaceAll(d, l.Group, "")
+	}
+	if l.Decimal != "" {
+		d = strings.Replace(d, l.Decimal, ".", 1)
+	}
+	if d == s || l.number(d) != s {
+		return s
+	}
+	return d
+}
@@ -0,0 +1,105 @@
+package table
+
+import (
+	"io"
+	"strings"
+)
+
+// MarkdownOptions holds the options of PrintMarkdown.
+type MarkdownOptions struct {
+	// AlignmentMarkers adds colons to the header separator row, like ---:, to align each column
+	// as set by Align or inferred from its values.
+	AlignmentMarkers bool
+}
+
+// PrintMarkdown prints the table as a GitHub flavored Markdown table, without formatting or borders.
+// Pipe characters in values are escaped. Separator and gap lines can't be expressed in Markdown
+// and are omitted, and footer rows follow the other rows. Notes, like the caption of HideConstant, and annotations follow the table
+// after a blank line.
+func (t *Table) PrintMarkdown(out io.Writer, o MarkdownOptions) error {
+	v := t.view()
+	headers := make([]string, len(v.headers))
+	widths := make([]int, len(v.headers))
+	for k, h := range v.headers {
+		headers[k] = markdownEscape(h)
+		// separator rows need at least three dashes
+		widths[k] = max(3, stringWidth(headers[k]))
+	}
+	rows := make([][]string, len(v.cells))
+	above := make([]string, len(v.src))
+	for j, cells := range v.cells {
+		rows[j] = make([]string, len(cells))
+		for k, c := range cells {
+			// Markdown can't span rows, so merged cells repeat the value of the block
+			if v.spanned(j, k) {
+				c = above[k]
+			}
+			above[k] = c
+			rows[j][k] = markdownEscape(c)
+			if url := t.link(v.rows[j], v.src[k]); url != "" && c != "" {
+				rows[j][k] = markdownLink(rows[j][k], url)
+			}
+			rows[j][k] += v.mark(j, k)
+			widths[k] = max(widths[k], stringWidth(rows[j][k]))
+		}
+	}
+	for _, cells := range v.footers {
+		footer := make([]string, len(cells))
+		for k, c := range cells {
+			footer[k] = markdownEscape(c)
+			widths[k] = max(widths[k], stringWidth(footer[k]))
+		}
+		rows = append(rows, footer)
+	}
+	p := t.printer(out)
+	// escape codes in values have no meaning in Markdown
+	p.level = ColorNone
+	p.clip = 0
+	writeRow := func(cells []string) {
+		for k, c := range cells {
+			p.writeString("| ")
+			p.writeString(c)
+			p.pad(widths[k] - stringWidth(c) + 1)
+		}
+		p.writeString("|")
+		p.endLine()
+	}
+	writeRow(headers)
+	seps := make([]string, len(widths))
+	for k, w := range widths {
+		var a Alignment
+		if o.AlignmentMarkers {
+			a = v.align[k]
+		}
+		switch a {
+		case Left:
+			seps[k] = ":" + strings.Repeat("-", w-1)
+		case Right:
+			seps[k] = strings.Repeat("-", w-1) + ":"
+		case Center:
+			seps[k] = ":" + strings.Repeat("-", w-2) + ":"
+		default:
+			seps[k] = strings.Repeat("-", w)
+		}
+	}
+	writeRow(seps)
+	for _, cells := range rows {
+		writeRow(cells)
+	}
+	if len(v.notes) > 0 || len(v.legend) > 0 {
+		p.endLine()
+		for _, n := range append(v.notes, v.legend...) {
+			p.writeString(markdownEscape(n))
+			p.endLine()
+		}
+	}
+	return p.close()
+}
+
+// markdownReplacer escapes the characters that would end a Markdown table cell or row.
+var markdownReplacer = strings.NewReplacer("|", `\|`, "\n", "<br>")
+
+// markdownEscape escapes the characters of s that would end a Markdown table cell or row.
+func markdownEscape(s string) string {
+	return markdownReplacer.Replace(s)
+}
@@ -0,0 +1,51 @@
+package table
+
+// Meta holds metadata attached to a row or a cell, like a URL, an ID or a severity.
+// It is ignored when printing text, but renderers of other formats may expose it,
+// for example as attributes or extra fields.
+type Meta map[string]string
+
+// SetRowMeta attaches metadata to the row at index row. Use row index -1 to denote the last row.
+// The metadata stays with the row when the table is sorted.
+func (t *Table) SetRowMeta(row int, m Meta) {
+	if r := t.rowAt(row); r != nil {
+		r.meta = m
+	}
+}
+
+// SetCellMeta attaches metadata to the cell of column col in the row at index row.
+// Use row index -1 to denote the last row. The metadata stays with the row when the table is sorted.
+func (t *Table) SetCellMeta(row, col int, m Meta) {
+	r := t.rowAt(row)
+	if r == nil || !t.validCol(col) {
+		return
+	}
+	r.cellMeta = withMeta(r.cellMeta, col, m)
+}
+
+// RowMeta returns the metadata attached to the row at index row, if any.
+func (t *Table) RowMeta(row int) Meta {
+	if r := t.rowAt(row); r != nil {
+		return r.meta
+	}
+	return nil
+}
+
+// CellMeta returns the metadata attached to the cell of column col in the row at index row, if any.
+func (t *Table) CellMeta(row, col int) Meta {
+	if r := t.rowAt(row); r != nil {
+		return r.cellMeta[col]
+	}
+	return nil
+}
+
+// rowAt returns the row at index j, or the last row if j is -1, or nil if there is no such row.
+func (t *Table) rowAt(j int) *row {
+	if j == -1 {
+		j = len(t.rows) - 1
+	}
+	if j < 0 || j >= len(t.rows) {
+		return nil
+	}
+	return &t.rows[j]
+}
@@ -0,0 +1,72 @@
+package table
+
+import (
+	"database/sql/driver"
+	"reflect"
+)
+
+// nullStyle holds how NULL values of a column are printed.
+type nullStyle struct {
+	text   string
+	format FormatFunc
+}
+
+// NullValue sets how NULL values of the listed columns are printed: as text, formatted by fn
+// if not nil, like NullValue("NULL", Format(Faint), 2). NULL values are nil values, nil pointers,
+// SQL values like sql.NullString that aren't valid, and the columns missing from short rows.
+// They are printed as empty cells by default, like empty strings, but unlike them they are
+// exported as JSON null and never counted by the aggregates.
+func (t *Table) NullValue(text string, fn FormatFunc, cols ...int) {
+	t.invalidate()
+	for _, col := range cols {
+		if t.validCol(col) {
+			t.nulls[col] = nullStyle{text: text, format: fn}
+		}
+	}
+}
+
+// nullable returns v with NULL values converted to nil, and the values of SQL types like
+// sql.NullInt64 converted to the value they hold, so they are printed and sorted by it.
+func nullable(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	// nil pointers are checked first, as types like *sql.NullString have their Value method
+	// on the value and would panic
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil
+	}
+	switch n := v.(type) {
+	case driver.Valuer:
+		d, err := n.Value()
+		if err != nil {
+			return v
+		}
+		if b, ok := d.([]byte); ok {
+			return string(b)
+		}
+		return d
+	}
+	return v
+}
+
+// null reports whether the value of column i of row r is NULL.
+func (r *row) null(i int) bool {
+	return !r.blank && r.value(i) == nil
+}
+
+// nullCell returns the text printed for column i of row r, which is NULL.
+func (t *Table) nullCell(r *row, i int) string {
+	if n, ok := t.nulls[i]; ok {
+		return n.text
+	}
+	return r.cell(i)
+}
+
+// nullFormat returns the format function of column i in row j if its value is NULL.
+func (t *Table) nullFormat(j, i int) FormatFunc {
+	if j < 0 || j >= len(t.rows) || !t.rows[j].null(i) {
+		return nil
+	}
+	return t.nulls[i].format
+}
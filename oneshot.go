@@ -0,0 +1,97 @@
+package table
+
+import (
+	"errors"
+	"io"
+	"reflect"
+)
+
+// An Option configures a table created by Fprint. Any method of Table can be used as an option
+// by wrapping it in a function:
+//
+//	table.Fprint(os.Stdout, headers, rows, func(t *table.Table) { t.MaxWidth(40, 1) })
+//
+// Options are applied before the rows are added.
+type Option func(*Table)
+
+// SortBy returns an option sorting the rows by the listed columns once they are added.
+func SortBy(cols ...int) Option {
+	return func(t *Table) {
+		t.sortBy = cols
+	}
+}
+
+// SortedBy returns an option marking the rows as already sorted by the listed columns,
+// which skips sorting them with SortBy. See Table.Sorted.
+func SortedBy(cols ...int) Option {
+	return func(t *Table) {
+		t.Sorted(cols...)
+	}
+}
+
+// Fprint prints a table with the given headers and rows to w, for throwaway tables where
+// creating and configuring a Table is overkill.
+// Any error returned is from the underlying io.Writer.
+func Fprint(w io.Writer, headers []string, rows [][]interface{}, opts ...Option) error {
+	t := New(headers...)
+	for _, o := range opts {
+		o(t)
+	}
+	t.Grow(len(rows))
+	for _, r := range rows {
+		t.Row(r...)
+	}
+	if len(t.sortBy) > 0 {
+		t.Sort(t.sortBy...)
+	}
+	return t.Print(w)
+}
+
+// PrintStructs prints a slice of structs, or of pointers to structs, to w as a table with one column
+// per exported field. The header of a column is the field name, or the name given by a `table` field tag.
+// Fields tagged with `table:"-"` are skipped. Nil pointers print as empty rows.
+func PrintStructs(w io.Writer, slice interface{}, opts ...Option) error {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice {
+		return errors.New("table: PrintStructs requires a slice of structs")
+	}
+	typ := v.Type().Elem()
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return errors.New("table: PrintStructs requires a slice of structs")
+	}
+	var (
+		headers []string
+		fields  []int
+	)
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		name := f.Tag.Get("table")
+		if f.PkgPath != "" || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		headers = append(headers, name)
+		fields = append(fields, i)
+	}
+	rows := make([][]interface{}, v.Len())
+	for j := range rows {
+		e := v.Index(j)
+		if e.Kind() == reflect.Ptr {
+			if e.IsNil() {
+				continue
+			}
+			e = e.Elem()
+		}
+		row := make([]interface{}, len(fields))
+		for k, i := range fields {
+			row[k] = e.Field(i).Interface()
+		}
+		rows[j] = row
+	}
+	return Fprint(w, headers, rows, opts...)
+}
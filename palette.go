@@ -0,0 +1,190 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Format256 returns a formatting function printing values in color code of the 256 color palette,
+// where codes 0 to 15 are the basic colors, 16 to 231 a 6x6x6 color cube and 232 to 255 grays.
+// At lower color levels, colors are replaced by the closest basic color.
+func Format256(code uint8) FormatFunc {
+	return formatSGR("38;5;" + strconv.Itoa(int(code)))
+}
+
+// Format256Bg returns a formatting function printing values on a background of color code of
+// the 256 color palette, see Format256.
+func Format256Bg(code uint8) FormatFunc {
+	return formatSGR("48;5;" + strconv.Itoa(int(code)))
+}
+
+// FormatRGB returns a formatting function printing values in the 24-bit color r, g, b.
+// At lower color levels, colors are replaced by the closest color of the palette.
+func FormatRGB(r, g, b uint8) FormatFunc {
+	return formatSGR("38;2;" + rgbParams(r, g, b))
+}
+
+// FormatRGBBg returns a formatting function printing values on a background of the 24-bit
+// color r, g, b, see FormatRGB.
+func FormatRGBBg(r, g, b uint8) FormatFunc {
+	return formatSGR("48;2;" + rgbParams(r, g, b))
+}
+
+func rgbParams(r, g, b uint8) string {
+	return strconv.Itoa(int(r)) + ";" + strconv.Itoa(int(g)) + ";" + strconv.Itoa(int(b))
+}
+
+// formatSGR returns a formatting function applying the SGR escape sequence with the given parameters.
+func formatSGR(params string) FormatFunc {
+	return func(s string) string {
+		return "\x1b[" + params + "m" + s + "\x1b[0m"
+	}
+}
+
+// basicRGB holds the colors of the 16 basic colors, as used by xterm.
+var basicRGB = [16][3]int{
+	{0, 0, 0}, {128, 0, 0}, {0, 128, 0}, {128, 128, 0},
+	{0, 0, 128}, {128, 0, 128}, {0, 128, 128}, {192, 192, 192},
+	{128, 128, 128}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{0, 0, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels holds the channel values of the color cube of the 256 color palette.
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// paletteRGB returns the color of code n of the 256 color palette.
+func paletteRGB(n int) [3]int {
+	switch {
+	case n < 16:
+		return basicRGB[n]
+	case n < 232:
+		n -= 16
+		return [3]int{cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6]}
+	}
+	g := 8 + 10*(n-232)
+	return [3]int{g, g, g}
+}
+
+// distance returns the squared distance between colors a and b.
+func distance(a, b [3]int) int {
+	d := 0
+	for i := range a {
+		d += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return d
+}
+
+// rgbTo256 returns the code of the 256 color palette closest to color c, from the color cube or the grays.
+func rgbTo256(c [3]int) int {
+	var idx [3]int
+	for i, v := range c {
+		for l := range cubeLevels {
+			if abs(cubeLevels[l]-v) < abs(cubeLevels[idx[i]]-v) {
+				idx[i] = l
+			}
+		}
+	}
+	cube := 16 + 36*idx[0] + 6*idx[1] + idx[2]
+	gray := ((c[0]+c[1]+c[2])/3 - 8 + 5) / 10
+	if gray < 0 {
+		gray = 0
+	} else if gray > 23 {
+		gray = 23
+	}
+	if distance(paletteRGB(232+gray), c) < distance(paletteRGB(cube), c) {
+		return 232 + gray
+	}
+	return cube
+}
+
+// basicCode returns the SGR parameter of the basic color closest to code n of the 256 color palette,
+// which must be in the range 0 to 255.
+func basicCode(n int, bg bool) string {
+	if n >= 16 {
+		c, best := paletteRGB(n), 0
+		for i := range basicRGB {
+			if distance(basicRGB[i], c) < distance(basicRGB[best], c) {
+				best = i
+			}
+		}
+		n = best
+	}
+	code := 30 + n
+	if n >= 8 {
+		code = 90 + n - 8
+	}
+	if bg {
+		code += colorBgAdd
+	}
+	return strconv.Itoa(code)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// downgradeANSI replaces the 256 and 24-bit colors of the escape sequences of s that are not
+// supported at color level l with the closest supported ones.
+func downgradeANSI(s string, l ColorLevel) string {
+	if strings.IndexByte(s, '\x1b') < 0 {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\x1b' {
+			b.WriteByte(s[i])
+			continue
+		}
+		end := skipEscape(s, i)
+		seq := s[i : end+1]
+		if len(seq) > 3 && seq[1] == '[' && seq[len(seq)-1] == 'm' {
+			seq = "\x1b[" + downgradeSGR(seq[2:len(seq)-1], l) + "m"
+		}
+		b.WriteString(seq)
+		i = end
+	}
+	return b.String()
+}
+
+// downgradeSGR rewrites the parameters of an SGR escape sequence for color level l.
+func downgradeSGR(params string, l ColorLevel) string {
+	p := strings.Split(params, ";")
+	out := make([]string, 0, len(p))
+	for i := 0; i < len(p); i++ {
+		if (p[i] != "38" && p[i] != "48") || i+1 >= len(p) {
+			out = append(out, p[i])
+			continue
+		}
+		bg := p[i] == "48"
+		switch {
+		case p[i+1] == "5" && i+2 < len(p):
+			// codes out of the palette are passed through, as there is no basic color for them
+			if n, err := strconv.Atoi(p[i+2]); l >= Color256 || err != nil || n < 0 || n > 255 {
+				out = append(out, p[i:i+3]...)
+			} else {
+				out = append(out, basicCode(n, bg))
+			}
+			i += 2
+		case p[i+1] == "2" && i+4 < len(p):
+			var c [3]int
+			for k := range c {
+				c[k], _ = strconv.Atoi(p[i+2+k])
+			}
+			switch {
+			case l >= ColorTrue:
+				out = append(out, p[i:i+5]...)
+			case l == Color256:
+				out = append(out, p[i], "5", strconv.Itoa(rgbTo256(c)))
+			default:
+				out = append(out, basicCode(rgbTo256(c), bg))
+			}
+			i += 4
+		default:
+			out = append(out, p[i])
+		}
+	}
+	return strings.Join(out, ";")
+}
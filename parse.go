@@ -0,0 +1,68 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A ParseFunc converts a string value of a column to a typed value, like a time or a number.
+// It returns false if s can't be parsed.
+type ParseFunc func(s string) (interface{}, bool)
+
+// Parse sets a function parsing the string values added to the listed columns, for text data
+// loaded from CSV or JSON. Parsed values are handled as if passed to Row, so that they are
+// formatted, aligned, exported and aggregated by type, and the columns sort by the parsed values.
+// Values that fail to parse are kept as strings. It must be called before adding the rows.
+func (t *Table) Parse(fn ParseFunc, cols ...int) {
+	for _, col := range cols {
+		if t.validCol(col) {
+			t.parsers[col] = fn
+			t.sortModes[col] = SortRaw
+		}
+	}
+}
+
+// parse returns v parsed by the parser of column i, or v if there is none or it fails.
+func (t *Table) parse(i int, v interface{}) interface{} {
+	fn := t.parsers[i]
+	s, ok := v.(string)
+	if fn == nil || !ok {
+		return v
+	}
+	if p, ok := fn(s); ok {
+		return p
+	}
+	return v
+}
+
+// ParseTime returns a ParseFunc parsing times in the given layout, as by time.Parse.
+func ParseTime(layout string) ParseFunc {
+	return func(s string) (interface{}, bool) {
+		tm, err := time.Parse(layout, strings.TrimSpace(s))
+		return tm, err == nil
+	}
+}
+
+// ParseUnits returns a ParseFunc parsing numbers followed by one of the given units, like
+// "250ms" or "1.5 GB", to a float64 multiplied by the factor of the unit. Numbers without
+// a unit are parsed as they are.
+func ParseUnits(units map[string]float64) ParseFunc {
+	return func(s string) (interface{}, bool) {
+		s = strings.TrimSpace(s)
+		end := len(s)
+		for end > 0 && !strings.ContainsAny(s[end-1:end], "0123456789.") {
+			end--
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(s[:end]), 64)
+		if err != nil {
+			return nil, false
+		}
+		unit := strings.TrimSpace(s[end:])
+		if unit == "" {
+			return f, true
+		}
+		factor, ok := units[unit]
+		return f * factor, ok
+	}
+}
@@ -0,0 +1,1051 @@
+package table
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+func appendWhitespace(b []byte, count int) []byte {
+	for i := 0; i < count; i++ {
+		b = append(b, ' ')
+	}
+	return b
+}
+
+// indexCol is the source column of the index column added by ShowIndex.
+const indexCol = -1
+
+// line is a kind of line printed between rows.
+type line int
+
+const (
+	lineNone line = iota
+	lineBlank
+	lineRule
+)
+
+// A view holds the columns and cells of a table as they are printed.
+type view struct {
+	src       []int  // source column of each printed column
+	rows      []int  // source row of each printed row
+	after     []line // line printed below each printed row
+	headers   []string
+	cells     [][]string
+	out       [][]string // formatted cells
+	footers   [][]string // footer cells
+	footerOut [][]string // formatted footer cells
+	widths    []int
+	align     []Alignment
+	padding   int
+	notes     []string   // lines printed below the table
+	marks     [][]string // markers of annotated cells, by printed row, or nil
+	spans     [][]bool   // cells merged with the cell above, by printed row, or nil
+	legend    []string   // annotations listed below the table
+	chunks    []*view    // column chunks printed when split by FreezeColumns, or nil
+}
+
+// baseView computes the columns, rows and cells of the table, with hidden rows omitted and
+// anonymized values replaced, but before any changes made for display only.
+// The rows appended by TrackRows are included if removed is set.
+func (t *Table) baseView(removed bool) *view {
+	t.compute()
+	v := &view{}
+	if t.indexHeader != "" {
+		v.src = append(v.src, indexCol)
+	}
+	for i := 0; i < t.columns; i++ {
+		v.src = append(v.src, i)
+	}
+	v.headers = make([]string, len(v.src))
+	for k, i := range v.src {
+		if i == indexCol {
+			v.headers[k] = t.indexHeader
+		} else {
+			v.headers[k] = t.headers[i]
+		}
+	}
+	for j := range t.rows {
+		r := &t.rows[j]
+		if r.removed && !removed {
+			continue
+		}
+		if t.hideZero && !r.blank && t.zero(r) {
+			if r.sep && len(v.after) > 0 {
+				v.after[len(v.after)-1] = lineRule
+			}
+			continue
+		}
+		cells := make([]string, len(v.src))
+		for k, i := range v.src {
+			if i == indexCol {
+				cells[k] = strconv.Itoa(r.index)
+			} else if r.null(i) {
+				cells[k] = t.nullCell(r, i)
+			} else if s, ok := t.relativeCell(r, i); ok {
+				cells[k] = s
+			} else {
+				cells[k] = r.cell(i)
+			}
+		}
+		v.rows = append(v.rows, j)
+		v.cells = append(v.cells, cells)
+		if r.sep {
+			v.after = append(v.after, lineRule)
+		} else {
+			v.after = append(v.after, lineNone)
+		}
+	}
+	t.rank(v.cells, v.src)
+	t.anonymizeView(v)
+	return v
+}
+
+// view computes the printed columns, rows, cells and column widths of the table.
+func (t *Table) view() *view {
+	t.track()
+	v := t.baseView(true)
+	v.padding = t.padding
+	if t.compact && v.padding > 1 {
+		v.padding = 1
+	}
+	if len(t.groupBy) > 0 {
+		for j := 0; j < len(v.rows)-1; j++ {
+			if v.after[j] == lineNone && !t.sameGroup(&t.rows[v.rows[j]], &t.rows[v.rows[j+1]]) {
+				v.after[j] = line(t.groupGap) + lineBlank
+			}
+		}
+	}
+	if n := t.separateEvery; n > 0 {
+		for j := n - 1; j < len(v.after)-1; j += n {
+			if v.after[j] == lineNone {
+				v.after[j] = lineRule
+			}
+		}
+	}
+	if t.compact {
+		for j, l := range v.after {
+			if l == lineBlank {
+				v.after[j] = lineNone
+			}
+		}
+	}
+	if t.collapse || t.compact {
+		for k := len(v.src) - 1; k >= 0; k-- {
+			if v.src[k] != indexCol && t.emptyColumn(v, k) {
+				v.drop(k)
+			}
+		}
+	}
+	if t.hideConstant {
+		var consts []string
+		for k := len(v.src) - 1; k >= 0; k-- {
+			if v.src[k] != indexCol && constantColumn(v, k) {
+				consts = append([]string{v.headers[k] + "=" + v.cells[0][k]}, consts...)
+				v.drop(k)
+			}
+		}
+		if t.constCaption && len(consts) > 0 {
+			v.notes = append(v.notes, strings.Join(consts, ", ")+" for all rows")
+		}
+	}
+	for k, i := range v.src {
+		if i != indexCol && t.autoPrecision[i] {
+			p := t.decimals(i)
+			for j, n := range v.rows {
+				switch val := t.rows[n].value(i); val.(type) {
+				case float32, float64:
+					v.cells[j][k] = formatValue(val, p)
+				}
+			}
+		}
+		if i != indexCol && (t.zeroPolicy[i] == ZeroHide || t.zeroPolicy[i] == ZeroDash) {
+			for _, cells := range v.cells {
+				if isZero(t.locales[i].delocalize(cells[k])) {
+					cells[k] = ""
+					if t.zeroPolicy[i] == ZeroDash {
+						cells[k] = "-"
+					}
+				}
+			}
+		}
+		if i != indexCol && t.alignDecimal[i] {
+			alignDecimals(v, k, t.locales[i])
+		}
+	}
+	// blank merged cells bottom up, so that each row is compared against the unchanged row above it
+	for j := len(v.cells) - 1; j > 0; j-- {
+		var merged []int
+		for k := range v.src {
+			if t.merged(v, j, k) {
+				merged = append(merged, k)
+			}
+		}
+		for _, k := range merged {
+			if v.spans == nil {
+				v.spans = make([][]bool, len(v.cells))
+			}
+			if v.spans[j] == nil {
+				v.spans[j] = make([]bool, len(v.src))
+			}
+			v.spans[j][k] = true
+			v.cells[j][k] = ""
+		}
+	}
+	var next map[cellKey]string
+	if t.cache != nil {
+		next = make(map[cellKey]string, len(t.cache))
+	}
+	v.out = make([][]string, len(v.cells))
+	for j, cells := range v.cells {
+		v.out[j] = make([]string, len(cells))
+		for k, c := range cells {
+			if _, ok := t.raw(v, j, k); ok {
+				v.out[j][k] = c
+			} else {
+				v.out[j][k] = t.formatCell(next, v.rows[j], j, v.src[k], c)
+			}
+		}
+	}
+	for _, f := range t.footers {
+		cells := make([]string, len(v.src))
+		out := make([]string, len(v.src))
+		for k, i := range v.src {
+			if i != indexCol && i < len(f) {
+				cells[k] = f[i]
+				out[k] = t.formatCell(next, footerRow, -1, i, f[i])
+			}
+		}
+		v.footers = append(v.footers, cells)
+		v.footerOut = append(v.footerOut, out)
+	}
+	if next != nil {
+		t.cache = next
+	}
+	t.annotate(v)
+	v.widths = make([]int, len(v.src))
+	for k := range v.src {
+		v.widths[k] = t.columnWidth(v, k)
+	}
+	limit := t.totalMaxWidth
+	if t.autoFit {
+		if w := terminalWidth(); w > 0 && (limit == 0 || w < limit) {
+			limit = w
+		}
+	}
+	v.align = make([]Alignment, len(v.src))
+	for k, i := range v.src {
+		switch {
+		case i == indexCol:
+			v.align[k] = Left
+		case t.align[i] != 0:
+			v.align[k] = t.align[i]
+		case t.kinds[i] == kindNumeric:
+			v.align[k] = Right
+		default:
+			v.align[k] = Left
+		}
+	}
+	if limit > 0 {
+		if t.frozen > 0 && t.frozen < len(v.src) && t.width(v) > limit {
+			v.chunks = t.chunk(v, limit)
+		}
+		t.abbreviate(v, limit)
+		t.fit(v, limit)
+	}
+	return v
+}
+
+// linesLen returns the visible length of the longest line of s.
+func linesLen(s string) int {
+	n := 0
+	for _, l := range strings.Split(s, "\n") {
+		n = max(n, stringWidth(l))
+	}
+	return n
+}
+
+// minWidth is the narrowest a column is shrunk to when fitting a table to a total width.
+const minWidth = 4
+
+// columnWidth returns the width of printed column k, fitting its header and formatted cells.
+func (t *Table) columnWidth(v *view, k int) int {
+	i := v.src[k]
+	w := stringWidth(t.formatHeaderValue(v.headers[k]))
+	for j := range v.out {
+		if raw, ok := t.raw(v, j, k); ok {
+			w = max(w, raw.width)
+		} else {
+			w = max(w, linesLen(v.out[j][k])+stringWidth(v.mark(j, k)))
+		}
+	}
+	for j := range v.footerOut {
+		w = max(w, stringWidth(v.footerOut[j][k]))
+	}
+	if i != indexCol && i < len(t.minWidths) {
+		w = max(w, t.minWidths[i])
+	}
+	if i != indexCol && t.maxWidths[i] > 0 && w > t.maxWidths[i] {
+		w = t.maxWidths[i]
+	}
+	return w
+}
+
+// abbreviate replaces headers by their abbreviations set with HeaderAbbreviations, saving the
+// most characters first, until the table is at most chars wide.
+func (t *Table) abbreviate(v *view, chars int) {
+	for t.width(v) > chars {
+		best, saved := -1, 0
+		for k, h := range v.headers {
+			a, ok := t.abbreviations[h]
+			if !ok {
+				continue
+			}
+			v.headers[k] = a
+			if d := v.widths[k] - t.columnWidth(v, k); d > saved {
+				best, saved = k, d
+			}
+			v.headers[k] = h
+		}
+		if best < 0 {
+			return
+		}
+		v.headers[best] = t.abbreviations[v.headers[best]]
+		v.widths[best] -= saved
+	}
+}
+
+// fit shrinks the widest columns of the view until the table is at most chars wide, if possible.
+func (t *Table) fit(v *view, chars int) {
+	for total := t.width(v); total > chars; total-- {
+		widest := -1
+		for k, w := range v.widths {
+			if v.src[k] != indexCol && w > minWidth && (widest < 0 || w > v.widths[widest]) {
+				widest = k
+			}
+		}
+		if widest < 0 {
+			return
+		}
+		v.widths[widest]--
+	}
+}
+
+// formatHeaderValue applies the header format or style, if any, to h.
+func (t *Table) formatHeaderValue(h string) string {
+	switch {
+	case t.formatHeader != nil:
+		return t.formatHeader(h)
+	case t.styleHeader != nil:
+		return t.styleHeader.fn(h)
+	}
+	return h
+}
+
+// fitCell returns the formatted value out of s, truncating s as needed for out to fit in w characters.
+// As format functions may change the length of a value, s is shortened by the width of out exceeding w
+// and formatted again with format.
+func fitCell(s, out string, w int, format func(string) string, trunc TruncateFunc) string {
+	n := stringWidth(out)
+	if n <= w {
+		return out
+	}
+	w -= n - stringWidth(s)
+	if w < 0 {
+		w = 0
+	}
+	return format(trunc(s, w))
+}
+
+// truncator returns the truncation function of column i.
+func (t *Table) truncator(i int) TruncateFunc {
+	if i != indexCol && t.truncators[i] != nil {
+		return t.truncators[i]
+	}
+	return truncate
+}
+
+// truncate shortens s to at most w characters, ending it with "..." if cut.
+// Wide characters count as two, and ANSI escape sequences are kept without being counted.
+func truncate(s string, w int) string {
+	if w < 0 {
+		w = 0
+	}
+	if stringWidth(s) <= w {
+		return s
+	}
+	if w <= 3 {
+		return cutWidth(s, w)
+	}
+	return cutWidth(s, w-3) + "..."
+}
+
+// TruncateCount is a TruncateFunc cutting the end of s and adding a marker with the number of
+// characters cut, like "a long val…(+42)", so that users know how much is hidden.
+// Use ExpandedCell to get the full value.
+func TruncateCount(s string, w int) string {
+	if stringWidth(s) <= w {
+		return s
+	}
+	n := utf8.RuneCountInString(stripANSI(s))
+	for keep := w - 4; keep >= 0; keep-- {
+		kept := cutWidth(s, keep)
+		marker := "…(+" + strconv.Itoa(n-utf8.RuneCountInString(stripANSI(kept))) + ")"
+		if stringWidth(kept)+stringWidth(marker) <= w {
+			return kept + marker
+		}
+	}
+	return truncate(s, w)
+}
+
+// TruncatePosition is where Truncation cuts values.
+type TruncatePosition int
+
+// Truncation positions
+const (
+	TruncateEnd TruncatePosition = iota
+	TruncateStart
+	TruncateMiddle
+)
+
+// Truncation returns a TruncateFunc cutting values at position pos and marking the cut with
+// ellipsis, like "…". Cutting the middle keeps both ends, which suits long file paths and IDs.
+// Styles of values cut at the start or the middle are removed.
+func Truncation(pos TruncatePosition, ellipsis string) TruncateFunc {
+	ew := stringWidth(ellipsis)
+	return func(s string, w int) string {
+		if w < 0 {
+			w = 0
+		}
+		if stringWidth(s) <= w {
+			return s
+		}
+		if w <= ew {
+			return cutWidth(s, w)
+		}
+		switch pos {
+		case TruncateStart:
+			return ellipsis + cutWidthEnd(stripANSI(s), w-ew)
+		case TruncateMiddle:
+			s = stripANSI(s)
+			head := (w - ew + 1) / 2
+			return cutWidth(s, head) + ellipsis + cutWidthEnd(s, w-ew-head)
+		}
+		return cutWidth(s, w-ew) + ellipsis
+	}
+}
+
+// ExpandedCell returns the full value of the cell of column col in the row at index row, as printed
+// before truncation. Use row index -1 to denote the last row. It can be used to show values cut by
+// MaxWidth or TotalMaxWidth, for example in a follow-up command.
+func (t *Table) ExpandedCell(row, col int) string {
+	if row == -1 {
+		row = len(t.rows) - 1
+	}
+	if row < 0 || row >= len(t.rows) || !t.validCol(col) {
+		return ""
+	}
+	// anonymized values depend on the other rows, so look the value up in the printed rows
+	v := t.baseView(true)
+	k := col
+	if t.indexHeader != "" {
+		k++
+	}
+	for j, n := range v.rows {
+		if n == row {
+			return v.cells[j][k]
+		}
+	}
+	return t.rows[row].cell(col)
+}
+
+// alignDecimals pads the numbers in printed column k, written in locale l, with spaces, so that
+// their integer and fraction parts have the same widths and the decimal points line up.
+func alignDecimals(v *view, k int, l Locale) {
+	intW, fracW := 0, 0
+	for _, cells := range v.cells {
+		if n, f, ok := splitDecimal(cells[k], l); ok {
+			intW, fracW = max(intW, n), max(fracW, f)
+		}
+	}
+	for _, cells := range v.cells {
+		if n, f, ok := splitDecimal(cells[k], l); ok {
+			cells[k] = strings.Repeat(" ", intW-n) + cells[k] + strings.Repeat(" ", fracW-f)
+		}
+	}
+}
+
+// splitDecimal returns the widths of the integer part and of the fraction part, including the
+// decimal point, of the number s, as written in locale l. It reports false if s is not a number.
+func splitDecimal(s string, l Locale) (int, int, bool) {
+	if _, err := strconv.ParseFloat(l.delocalize(s), 64); err != nil {
+		return 0, 0, false
+	}
+	point := l.Decimal
+	if point == "" {
+		point = "."
+	}
+	if dot := strings.Index(s, point); dot >= 0 {
+		return dot, len(s) - dot, true
+	}
+	return len(s), 0, true
+}
+
+// emptyColumn reports whether all cells of printed column k are empty.
+func (t *Table) emptyColumn(v *view, k int) bool {
+	for _, cells := range v.cells {
+		if !t.empty(cells[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+// drop removes printed column k from the view.
+func (v *view) drop(k int) {
+	v.src = append(v.src[:k], v.src[k+1:]...)
+	v.headers = append(v.headers[:k], v.headers[k+1:]...)
+	for j, cells := range v.cells {
+		v.cells[j] = append(cells[:k], cells[k+1:]...)
+	}
+}
+
+// Print prints the table headers and rows to a io.Writer.
+// Any error returned is from the underlying io.Writer.
+func (t *Table) Print(out io.Writer) error {
+	v := t.view()
+	parts := v.parts()
+	p := t.printer(out)
+	t.printStatus(p, parts[0], Top)
+	t.printTitle(p, parts[0])
+	for n, c := range parts {
+		if n > 0 {
+			p.endLine()
+		}
+		t.printHeader(p, c)
+		t.printRows(p, c)
+		t.printFooters(p, c)
+		if b, ok := borders[t.border]; ok {
+			t.printBorder(p, c, b.bl, b.bm, b.br)
+		}
+	}
+	t.printNotes(p, v)
+	t.printStatus(p, parts[0], Bottom)
+	if t.onDiagnostics != nil {
+		t.onDiagnostics(t.diagnose(v))
+	}
+	return p.close()
+}
+
+// width returns the total width of the printed table, including padding.
+func (t *Table) width(v *view) int {
+	w := v.padding * (len(v.widths) - 1)
+	if t.bordered() {
+		w = borderWidth(v)
+	}
+	for _, cw := range v.widths {
+		w += cw
+	}
+	return w
+}
+
+// printRule prints a horizontal line spanning the full table width.
+func (t *Table) printRule(p *printer, v *view) {
+	if b, ok := borders[t.border]; ok {
+		t.printBorder(p, v, b.ml, b.mm, b.mr)
+		return
+	}
+	l := strings.Repeat("-", t.width(v))
+	if t.formatBorder != nil {
+		l = t.formatBorder(l)
+	}
+	p.writeString(l)
+	p.endLine()
+}
+
+// spanned reports whether printed cell k of printed row j is merged with the cell above it.
+func (v *view) spanned(j, k int) bool {
+	return j < len(v.spans) && v.spans[j] != nil && v.spans[j][k]
+}
+
+// span returns the number of printed rows covered by printed cell k of printed row j:
+// the row itself and the rows merged with it.
+func (v *view) span(j, k int) int {
+	n := 1
+	for v.spanned(j+n, k) {
+		n++
+	}
+	return n
+}
+
+// printSpanRule prints a horizontal line like printRule, but left open over the columns set in
+// spans, whose merged cells continue below it.
+func (t *Table) printSpanRule(p *printer, v *view, spans []bool) {
+	var l strings.Builder
+	if b, ok := borders[t.border]; ok {
+		last := len(v.widths) - 1
+		for k, w := range v.widths {
+			switch {
+			case k == 0 && spans[k]:
+				l.WriteString(b.v)
+			case k == 0:
+				l.WriteString(b.ml)
+			case spans[k-1] && spans[k]:
+				l.WriteString(b.v)
+			case spans[k-1]:
+				l.WriteString(b.ml)
+			case spans[k]:
+				l.WriteString(b.mr)
+			default:
+				l.WriteString(b.mm)
+			}
+			if spans[k] {
+				l.WriteString(strings.Repeat(" ", w+2))
+			} else {
+				l.WriteString(strings.Repeat(b.h, w+2))
+			}
+			if k == last && spans[k] {
+				l.WriteString(b.v)
+			} else if k == last {
+				l.WriteString(b.mr)
+			}
+		}
+	} else {
+		for k, w := range v.widths {
+			fill := "-"
+			if spans[k] {
+				fill = " "
+			}
+			if k > 0 {
+				gap := "-"
+				if spans[k-1] || spans[k] {
+					gap = " "
+				}
+				l.WriteString(strings.Repeat(gap, v.padding))
+			}
+			l.WriteString(strings.Repeat(fill, w))
+		}
+	}
+	p.writeString(t.borderString(l.String()))
+	p.endLine()
+}
+
+// Layout describes how a table is printed, as returned by Table.Layout.
+type Layout struct {
+	// Columns holds the headers of the printed columns, which may differ from the table columns
+	// when columns are hidden or an index column is shown. Tables split by FreezeColumns list the
+	// columns of each chunk in turn, and Width is the width of the widest chunk.
+	Columns []string
+	// Widths holds the width in characters of each printed column, not including padding.
+	Widths []int
+	// Width is the total width of the table in characters, including padding.
+	Width int
+	// Rows is the number of printed rows.
+	Rows int
+	// Lines is the number of printed lines, including the header and any separator lines.
+	Lines int
+}
+
+// Layout computes how the table is printed, without printing it.
+// It can be used to choose how to print a table, for example when it does not fit the screen.
+func (t *Table) Layout() Layout {
+	return t.layout(t.view())
+}
+
+// layout computes the layout of view v. The columns of split tables are listed chunk by chunk.
+func (t *Table) layout(v *view) Layout {
+	l := Layout{
+		Rows:  len(v.cells),
+		Lines: len(v.notes) + len(v.legend),
+	}
+	parts := v.parts()
+	for _, c := range parts {
+		l.Columns = append(l.Columns, c.headers...)
+		l.Widths = append(l.Widths, c.widths...)
+		l.Width = max(l.Width, t.width(c))
+		l.Lines += t.bodyLines(c)
+	}
+	// blank lines between chunks
+	l.Lines += len(parts) - 1
+	if t.status != nil {
+		l.Lines++
+	}
+	l.Lines += len(t.titleLines(t.width(parts[0])))
+	return l
+}
+
+// bodyLines returns the number of lines printed for the header, rows and footers of view v.
+func (t *Table) bodyLines(v *view) int {
+	n := 1 + len(v.cells)
+	if t.headerRule != 0 && !t.bordered() {
+		n++
+	}
+	if t.bordered() {
+		// top, below the header and bottom lines
+		n += 3
+	}
+	if len(v.footers) > 0 {
+		n += 1 + len(v.footers)
+	}
+	for j, a := range v.after {
+		if a != lineNone {
+			n++
+		}
+		n += len(t.rowLines(v, j)[0]) - 1
+	}
+	return n
+}
+
+// SaveWidths returns the printed width of each column of the table, or 0 for hidden columns.
+// Loading them into another table with UseWidths keeps the columns at the same positions,
+// for example across pages of results or successive runs of a command.
+func (t *Table) SaveWidths() []int {
+	v := t.view()
+	widths := make([]int, t.columns)
+	for k, i := range v.src {
+		if i != indexCol {
+			widths[i] = v.widths[k]
+		}
+	}
+	return widths
+}
+
+// UseWidths sets the minimum width of each column, as returned by SaveWidths.
+// Columns with wider values still grow, unless limited by MaxWidth.
+func (t *Table) UseWidths(widths []int) {
+	t.minWidths = append([]int(nil), widths...)
+}
+
+// Bytes returns the table as printed by Print.
+func (t *Table) Bytes() []byte {
+	return t.AppendTo(nil)
+}
+
+// AppendTo appends the table as printed by Print to dst and returns the extended buffer.
+func (t *Table) AppendTo(dst []byte) []byte {
+	w := appendWriter{b: dst}
+	t.Print(&w)
+	return w.b
+}
+
+// appendWriter is an io.Writer appending to a byte slice.
+type appendWriter struct {
+	b []byte
+}
+
+func (w *appendWriter) Write(p []byte) (int, error) {
+	w.b = append(w.b, p...)
+	return len(p), nil
+}
+
+// PrintHeader prints only the header line of the table, using the same column widths as Print.
+// It can be used to repeat the header of a long listing.
+func (t *Table) PrintHeader(out io.Writer) error {
+	p := t.printer(out)
+	t.printHeader(p, t.view())
+	return p.close()
+}
+
+// PrintRows prints the table rows without the header line, using the same column widths as Print.
+// It can be used to append rows under a previously printed header.
+func (t *Table) PrintRows(out io.Writer) error {
+	p := t.printer(out)
+	v := t.view()
+	t.printRows(p, v)
+	t.printNotes(p, v)
+	return p.close()
+}
+
+func (t *Table) printHeader(p *printer, v *view) {
+	b, bordered := borders[t.border]
+	if bordered {
+		t.printBorder(p, v, b.tl, b.tm, b.tr)
+	}
+	last := len(v.src) - 1
+	for k, h := range v.headers {
+		h = fitCell(h, t.formatHeaderValue(h), v.widths[k], t.formatHeaderValue, truncate)
+		t.writeCell(p, v, k, h, k == last)
+	}
+	p.endLine()
+	if bordered {
+		t.printBorder(p, v, b.ml, b.mm, b.mr)
+	} else if t.headerRule != 0 {
+		var l strings.Builder
+		for k, w := range v.widths {
+			if k > 0 {
+				l.WriteString(strings.Repeat(" ", v.padding))
+			}
+			l.WriteString(strings.Repeat(string(t.headerRule), w))
+		}
+		p.writeString(t.borderString(l.String()))
+		p.endLine()
+	}
+}
+
+// writeCell writes the formatted value s of printed column k, aligned within the column width
+// and followed by the padding between columns.
+func (t *Table) writeCell(p *printer, v *view, k int, s string, last bool) {
+	t.writeAligned(p, v, k, s, stringWidth(s), p.writeString, last)
+}
+
+// writeAligned writes s, which is n characters wide, with write as the cell of printed column k.
+func (t *Table) writeAligned(p *printer, v *view, k int, s string, n int, write func(string), last bool) {
+	space := v.widths[k] - n
+	before := 0
+	switch v.align[k] {
+	case Right:
+		before = space
+	case Center:
+		before = space / 2
+	}
+	if b, ok := borders[t.border]; ok {
+		p.writeString(t.borderString(b.v))
+		p.pad(before + 1)
+		write(s)
+		p.pad(space - before + 1)
+		if last {
+			p.writeString(t.borderString(b.v))
+		}
+		return
+	}
+	after := space - before + v.padding
+	if last {
+		after -= v.padding
+		if t.trailing != TrailingPad {
+			after = 0
+		}
+	}
+	p.pad(before)
+	write(s)
+	p.pad(after)
+}
+
+func (t *Table) printRows(p *printer, v *view) {
+	last := len(v.src) - 1
+	total := len(v.cells)
+	progress := t.progress != nil && total >= t.progressMin
+	step := max(total/100, 1)
+	for j, cells := range v.cells {
+		if progress && j%step == 0 {
+			t.progress(j, total)
+		}
+		lines := t.rowLines(v, j)
+		for l := range lines[0] {
+			for k := range cells {
+				if raw, ok := t.raw(v, j, k); ok && l == 0 {
+					t.writeAligned(p, v, k, raw.s, raw.width, p.writeRaw, k == last)
+					continue
+				}
+				var r string
+				if l < len(lines[k]) {
+					r = lines[k][l]
+				}
+				t.writeCell(p, v, k, r, k == last)
+			}
+			if t.onRowPrinted == nil {
+				p.endLine()
+				continue
+			}
+			text := p.text()
+			p.endLine()
+			t.onRowPrinted(v.rows[j], text)
+		}
+		switch v.after[j] {
+		case lineRule:
+			if j+1 < len(v.spans) && v.spans[j+1] != nil {
+				t.printSpanRule(p, v, v.spans[j+1])
+			} else {
+				t.printRule(p, v)
+			}
+		case lineBlank:
+			if t.bordered() {
+				for k := range v.src {
+					t.writeCell(p, v, k, "", k == len(v.src)-1)
+				}
+			}
+			p.endLine()
+		}
+	}
+	if progress {
+		t.progress(total, total)
+	}
+}
+
+// rowLines returns the formatted lines of each cell of printed row j, fitted to the column
+// widths. Cells are split at newlines, and wrapped if set by Wrap. The first slice is padded
+// to the number of lines of the row.
+func (t *Table) rowLines(v *view, j int) [][]string {
+	lines := make([][]string, len(v.src))
+	n := 1
+	for k, c := range v.cells[j] {
+		if _, ok := t.raw(v, j, k); ok {
+			continue
+		}
+		i := v.src[k]
+		format := func(s string) string {
+			return t.formatCell(nil, v.rows[j], j, i, s)
+		}
+		mark := v.mark(j, k)
+		width := v.widths[k] - stringWidth(mark)
+		parts := []string{c}
+		if strings.Contains(c, "\n") {
+			parts = strings.Split(c, "\n")
+		}
+		for _, part := range parts {
+			out := v.out[j][k]
+			if len(parts) > 1 {
+				out = format(part)
+			}
+			if i != indexCol && t.wrap[i] && stringWidth(out) > width {
+				for _, l := range wrapWords(part, width-(stringWidth(out)-stringWidth(part))) {
+					lines[k] = append(lines[k], fitCell(l, format(l), width, format, t.truncator(i)))
+				}
+			} else {
+				lines[k] = append(lines[k], fitCell(part, out, width, format, t.truncator(i)))
+			}
+		}
+		if url := t.link(v.rows[j], i); url != "" {
+			for l := range lines[k] {
+				if lines[k][l] != "" {
+					lines[k][l] = Link(url)(lines[k][l])
+				}
+			}
+		}
+		lines[k][len(lines[k])-1] += mark
+		n = max(n, len(lines[k]))
+	}
+	for len(lines[0]) < n {
+		lines[0] = append(lines[0], "")
+	}
+	return lines
+}
+
+// printFooters prints the footer rows below a separator line.
+func (t *Table) printFooters(p *printer, v *view) {
+	if len(v.footers) == 0 {
+		return
+	}
+	t.printRule(p, v)
+	last := len(v.src) - 1
+	for j, cells := range v.footers {
+		for k, c := range cells {
+			r := fitCell(c, v.footerOut[j][k], v.widths[k], func(s string) string {
+				return t.formatCell(nil, footerRow, -1, v.src[k], s)
+			}, t.truncator(v.src[k]))
+			t.writeCell(p, v, k, r, k == last)
+		}
+		p.endLine()
+	}
+}
+
+// printNotes prints the notes and the legend of annotations below the table.
+func (t *Table) printNotes(p *printer, v *view) {
+	for _, n := range v.notes {
+		p.writeString(n)
+		p.endLine()
+	}
+	for _, l := range v.legend {
+		p.writeString(l)
+		p.endLine()
+	}
+}
+
+// A printer writes the lines of a table to an io.Writer.
+// After the first write error, all further writes are skipped and close returns the error.
+type printer struct {
+	out     io.Writer
+	eol     string
+	final   bool       // end the last line with eol
+	line    []byte     // current line
+	pending bool       // a line has been written without its line ending
+	level   ColorLevel // escape codes beyond the level are removed or downgraded
+	trim    bool       // remove trailing whitespace
+	clip    int        // cut lines to this width, if not 0
+	err     error
+}
+
+// printer returns a printer writing to out with the line endings of the table.
+func (t *Table) printer(out io.Writer) *printer {
+	p := &printer{
+		out:   out,
+		eol:   t.lineEnding(),
+		final: !t.noFinalEOL,
+		level: t.color(out),
+		trim:  t.trailing == TrailingTrim,
+		clip:  t.clipWidth,
+	}
+	if f, ok := out.(*os.File); ok && p.level != ColorNone && !enableEscapes(f) {
+		p.level = ColorNone
+	}
+	return p
+}
+
+// lineEnding returns the line ending set by LineEnding, or "\n".
+func (t *Table) lineEnding() string {
+	if t.eol == "" {
+		return "\n"
+	}
+	return t.eol
+}
+
+func (p *printer) writeString(s string) {
+	switch {
+	case p.level == ColorNone:
+		s = stripANSI(s)
+	case p.level < ColorTrue:
+		s = downgradeANSI(s, p.level)
+	}
+	p.line = append(p.line, s...)
+}
+
+// writeRaw adds s to the current line, keeping any escape codes.
+func (p *printer) writeRaw(s string) {
+	p.line = append(p.line, s...)
+}
+
+// finish applies the clipping and whitespace removal of the printer to line.
+func (p *printer) finish(line []byte) []byte {
+	if p.clip > 0 && stringWidth(string(line)) > p.clip {
+		line = append(line[:0], cutWidth(string(line), p.clip)...)
+	}
+	if p.trim {
+		line = bytes.TrimRight(line, " ")
+	}
+	return line
+}
+
+// text returns the current line as it will be written.
+func (p *printer) text() string {
+	return string(p.finish(append([]byte(nil), p.line...)))
+}
+
+// pad adds count whitespaces to the current line.
+func (p *printer) pad(count int) {
+	p.line = appendWhitespace(p.line, count)
+}
+
+// endLine writes the current line. Its line ending is held back until the next line,
+// so that close can leave it out.
+func (p *printer) endLine() {
+	if p.err == nil && p.pending {
+		_, p.err = io.WriteString(p.out, p.eol)
+	}
+	p.line = p.finish(p.line)
+	if p.err == nil {
+		_, p.err = p.out.Write(p.line)
+	}
+	p.line = p.line[:0]
+	p.pending = true
+}
+
+// close ends the last line and returns the first write error, if any.
+func (p *printer) close() error {
+	if p.err == nil && p.pending && p.final {
+		_, p.err = io.WriteString(p.out, p.eol)
+	}
+	p.pending = false
+	return p.err
+}
@@ -0,0 +1,149 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A Profile holds appearance settings of tables that can be saved as JSON, for example in the
+// config file of a command, and applied to new tables with ApplyProfile.
+// Formats are given as lists of attribute names, like ["hiyellow", "bold"].
+type Profile struct {
+	// Theme names a registered theme, like "plain" or "dark", see RegisterTheme.
+	Theme string `json:"theme,omitempty"`
+	// Header lists the attributes of the header format.
+	Header []string `json:"header,omitempty"`
+	// Columns holds the settings of columns by header.
+	Columns map[string]ColumnProfile `json:"columns,omitempty"`
+}
+
+// ColumnProfile holds the settings of a column in a Profile.
+type ColumnProfile struct {
+	// Align is "left", "right" or "center".
+	Align string `json:"align,omitempty"`
+	// MaxWidth is the max width in characters of the column.
+	MaxWidth int `json:"maxWidth,omitempty"`
+	// Format lists the attributes of the column format.
+	Format []string `json:"format,omitempty"`
+	// Null is the text printed for NULL values, see NullValue.
+	Null string `json:"null,omitempty"`
+}
+
+var alignmentNames = map[string]Alignment{
+	"left":   Left,
+	"right":  Right,
+	"center": Center,
+}
+
+var attributeNames = map[string]CodeANSI{
+	"black": Black, "red": Red, "green": Green, "yellow": Yellow,
+	"blue": Blue, "magenta": Magenta, "cyan": Cyan, "white": White,
+	"hiblack": HiBlack, "hired": HiRed, "higreen": HiGreen, "hiyellow": HiYellow,
+	"hiblue": HiBlue, "himagenta": HiMagenta, "hicyan": HiCyan, "hiwhite": HiWhite,
+	"bold": Bold, "faint": Faint, "italic": Italic, "underline": Underline,
+	"reverse": Reverse, "crossedout": CrossedOut,
+}
+
+// ApplyProfile applies the settings of p to the table. Settings of columns with headers not in
+// the table are ignored, so the same profile can be applied to different tables.
+// It returns an error for unknown theme, alignment or attribute names, without applying any setting.
+func (t *Table) ApplyProfile(p Profile) error {
+	var theme *Theme
+	if p.Theme != "" {
+		th, ok := LookupTheme(p.Theme)
+		if !ok {
+			return fmt.Errorf("table: unknown theme %q", p.Theme)
+		}
+		theme = &th
+	}
+	header, err := profileFormat(p.Header)
+	if err != nil {
+		return err
+	}
+	type column struct {
+		align  Alignment
+		format FormatFunc
+	}
+	cols := make(map[string]column, len(p.Columns))
+	for h, c := range p.Columns {
+		var col column
+		if c.Align != "" {
+			var ok bool
+			if col.align, ok = alignmentNames[strings.ToLower(c.Align)]; !ok {
+				return fmt.Errorf("table: unknown alignment %q of column %q", c.Align, h)
+			}
+		}
+		if col.format, err = profileFormat(c.Format); err != nil {
+			return err
+		}
+		cols[h] = col
+	}
+	if theme != nil {
+		t.SetTheme(*theme)
+	}
+	if header != nil {
+		t.FormatHeader(header)
+	}
+	for i, h := range t.headers {
+		c, ok := cols[h]
+		if !ok {
+			continue
+		}
+		if c.align != 0 {
+			t.Align(c.align, i)
+		}
+		if w := p.Columns[h].MaxWidth; w > 0 {
+			t.MaxWidth(w, i)
+		}
+		if c.format != nil {
+			t.FormatCols(c.format, i)
+		}
+		if n := p.Columns[h].Null; n != "" {
+			t.NullValue(n, nil, i)
+		}
+	}
+	t.profile = p
+	return nil
+}
+
+// profileFormat returns the format applying the named attributes, or nil if there are none.
+func profileFormat(names []string) (FormatFunc, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	attrs := make([]CodeANSI, len(names))
+	for i, n := range names {
+		a, ok := attributeNames[strings.ToLower(n)]
+		if !ok {
+			return nil, fmt.Errorf("table: unknown attribute %q", n)
+		}
+		attrs[i] = a
+	}
+	return Format(attrs...), nil
+}
+
+// Profile returns the settings of the table as a Profile: the theme and formats of the last
+// applied profile, and the alignment and max width of each column.
+func (t *Table) Profile() Profile {
+	p := Profile{
+		Theme:  t.profile.Theme,
+		Header: t.profile.Header,
+	}
+	for i, h := range t.headers {
+		c := t.profile.Columns[h]
+		c.Align, c.MaxWidth = "", t.maxWidths[i]
+		for name, a := range alignmentNames {
+			if t.align[i] == a {
+				c.Align = name
+			}
+		}
+		if c.Align == "" && c.MaxWidth == 0 && len(c.Format) == 0 && c.Null == "" {
+			continue
+		}
+		if p.Columns == nil {
+			p.Columns = make(map[string]ColumnProfile)
+		}
+		p.Columns[h] = c
+	}
+	return p
+}
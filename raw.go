@@ -0,0 +1,25 @@
+package table
+
+// A RawCell is a cell value printed exactly as given, see Raw.
+type RawCell struct {
+	s     string
+	width int
+}
+
+// Raw returns a cell value for Row that is printed exactly as s, for values embedding their own
+// escape sequences or pre-rendered fragments, like hyperlinks or inline images. The caller asserts
+// that s takes width characters on screen, which is used for the column width instead of measuring s.
+// Raw values are never formatted, truncated or stripped of escape codes, even with ColorNone.
+func Raw(s string, width int) RawCell {
+	return RawCell{s: s, width: width}
+}
+
+// raw returns the raw value of cell k in row j of the view, if it was added with Raw.
+func (t *Table) raw(v *view, j, k int) (RawCell, bool) {
+	i := v.src[k]
+	if i == indexCol {
+		return RawCell{}, false
+	}
+	r, ok := t.rows[v.rows[j]].value(i).(RawCell)
+	return r, ok
+}
@@ -0,0 +1,149 @@
+package table
+
+import (
+	"html"
+	"io"
+	"strings"
+)
+
+// A Report holds several titled tables rendered one after the other, like the summary printed
+// at the end of a command run. Columns of tables with the same headers are printed with the
+// same widths, so that they line up.
+type Report struct {
+	sections []section
+}
+
+type section struct {
+	title string
+	t     *Table
+}
+
+// NewReport creates a new empty report.
+func NewReport() *Report {
+	return &Report{}
+}
+
+// Add adds a table with the given title to the report.
+func (r *Report) Add(title string, t *Table) {
+	r.sections = append(r.sections, section{title: title, t: t})
+}
+
+// ApplyProfile applies p to all tables of the report, for a consistent look.
+func (r *Report) ApplyProfile(p Profile) error {
+	for _, s := range r.sections {
+		if err := s.t.ApplyProfile(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sharedWidths sets the min widths of tables with the same headers to the widest of them,
+// and returns a function restoring the previous min widths.
+func (r *Report) sharedWidths() (restore func()) {
+	saved := make([][]int, len(r.sections))
+	widths := make(map[string][]int)
+	for n, s := range r.sections {
+		saved[n] = s.t.minWidths
+		key := strings.Join(s.t.headers, "\x00")
+		w := s.t.SaveWidths()
+		for i, cw := range widths[key] {
+			w[i] = max(w[i], cw)
+		}
+		widths[key] = w
+	}
+	for _, s := range r.sections {
+		s.t.minWidths = widths[strings.Join(s.t.headers, "\x00")]
+	}
+	return func() {
+		for n, s := range r.sections {
+			s.t.minWidths = saved[n]
+		}
+	}
+}
+
+// printer returns a printer writing the lines around the table of section n to out like the
+// table, with its line endings: clipped and with escape codes removed as set for the table, or
+// for the Markdown and HTML output if markup is set. The last line of the previous table, left
+// open if FinalNewline is off, is ended first.
+func (r *Report) printer(n int, out io.Writer, markup bool) *printer {
+	p := r.sections[n].t.printer(out)
+	p.final = true
+	if markup {
+		p.level, p.clip = ColorNone, 0
+	}
+	p.pending = n > 0 && r.sections[n-1].t.noFinalEOL
+	return p
+}
+
+// Print prints the title and table of each section, separated by blank lines.
+func (r *Report) Print(out io.Writer) error {
+	defer r.sharedWidths()()
+	for n, s := range r.sections {
+		p := r.printer(n, out, false)
+		if n > 0 {
+			p.endLine()
+		}
+		if s.title != "" {
+			p.writeString(s.title)
+			p.endLine()
+		}
+		if err := p.close(); err != nil {
+			return err
+		}
+		if err := s.t.Print(out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PrintMarkdown prints each section as a level 2 heading followed by its table.
+func (r *Report) PrintMarkdown(out io.Writer, o MarkdownOptions) error {
+	for n, s := range r.sections {
+		p := r.printer(n, out, true)
+		if n > 0 {
+			p.endLine()
+		}
+		if s.title != "" {
+			p.writeString("## " + s.title)
+			p.endLine()
+			p.endLine()
+		}
+		if err := p.close(); err != nil {
+			return err
+		}
+		if err := s.t.PrintMarkdown(out, o); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PrintHTML prints each section as a section element with a heading and the table.
+func (r *Report) PrintHTML(out io.Writer, o HTMLOptions) error {
+	for n, s := range r.sections {
+		p := r.printer(n, out, true)
+		p.writeString("<section>")
+		p.endLine()
+		if s.title != "" {
+			p.writeString("<h2>" + html.EscapeString(s.title) + "</h2>")
+			p.endLine()
+		}
+		if err := p.close(); err != nil {
+			return err
+		}
+		if err := s.t.PrintHTML(out, o); err != nil {
+			return err
+		}
+		p = r.printer(n, out, true)
+		p.pending = s.t.noFinalEOL
+		p.final = n < len(r.sections)-1 || !s.t.noFinalEOL
+		p.writeString("</section>")
+		p.endLine()
+		if err := p.close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package table
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A Rule formats the numeric values of a column that are greater than or equal to Min.
+type Rule struct {
+	Min    float64
+	Format FormatFunc
+}
+
+// ruleSet holds the rules of a column. If desc is set, rules match values from their Min down.
+// If percentile is set, rules match the percentile rank of values in the column instead.
+type ruleSet struct {
+	rules      []Rule
+	desc       bool
+	percentile bool
+}
+
+// FormatRules sets threshold rules for the numeric values of column col, like "12", "3.5" or "20%".
+// Each value is formatted by the rule with the highest Min not above the value.
+// Values below all thresholds and non-numeric values are formatted as usual.
+func (t *Table) FormatRules(col int, rules ...Rule) {
+	t.setRules(col, ruleSet{rules: rules})
+}
+
+// setRules sets the rules of column col.
+func (t *Table) setRules(col int, rs ruleSet) {
+	t.invalidate()
+	if t.validCol(col) {
+		t.rules[col] = rs
+	}
+}
+
+// TrafficLight formats the numeric values of column col in green, values from warn in yellow
+// and values from crit in red. If crit is below warn, lower values are worse instead, like for
+// free disk space: values up to warn are yellow and values up to crit are red.
+func (t *Table) TrafficLight(col int, warn, crit float64) {
+	green, yellow, red := Format(Green), Format(Yellow), Format(Red)
+	if crit >= warn {
+		t.FormatRules(col, Rule{math.Inf(-1), green}, Rule{warn, yellow}, Rule{crit, red})
+		return
+	}
+	t.setRules(col, ruleSet{rules: []Rule{{math.Inf(1), green}, {warn, yellow}, {crit, red}}, desc: true})
+}
+
+// PercentileRules sets rules for the numeric values of column col by their percentile rank,
+// the percentage of values in the column below them, from 0 to 100. Ranks are computed over
+// the printed rows each time the table is printed. A rule with a nil Format keeps the values
+// from its Min formatted as usual, which allows to format the lowest ranks only.
+func (t *Table) PercentileRules(col int, rules ...Rule) {
+	t.setRules(col, ruleSet{rules: rules, percentile: true})
+}
+
+// Percentiles formats the values of column col in the top decile with top and those in the
+// bottom quartile with bottom. Either may be nil.
+func (t *Table) Percentiles(col int, top, bottom FormatFunc) {
+	t.PercentileRules(col, Rule{0, bottom}, Rule{25, nil}, Rule{90, top})
+}
+
+// rank computes the sorted values of the columns with percentile rules from rows.
+// The format cache is cleared when they change, since the format of a cell then depends on
+// the other cells of its column.
+func (t *Table) rank(rows [][]string, src []int) {
+	for k, i := range src {
+		rs, ok := t.rules[i]
+		if !ok || !rs.percentile {
+			continue
+		}
+		var values []float64
+		for _, cells := range rows {
+			if n, ok := ruleValue(t.locales[i].delocalize(cells[k])); ok {
+				values = append(values, n)
+			}
+		}
+		sort.Float64s(values)
+		if !equalFloats(values, t.ranks[i]) {
+			t.invalidate()
+		}
+		t.ranks[i] = values
+	}
+}
+
+// equalFloats reports whether a and b hold the same values.
+func equalFloats(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ruleValue parses s as a number for rules.
+func ruleValue(s string) (float64, bool) {
+	n, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	return n, err == nil
+}
+
+// ruleFormat returns the format set by FormatRules for value s of column i in row j, if any.
+// Percentile rules do not apply to footers.
+func (t *Table) ruleFormat(j, i int, s string) FormatFunc {
+	rs, ok := t.rules[i]
+	if !ok {
+		return nil
+	}
+	n, ok := ruleValue(s)
+	if !ok {
+		return nil
+	}
+	if rs.percentile {
+		values := t.ranks[i]
+		if j < 0 || len(values) == 0 {
+			return nil
+		}
+		n = 100 * float64(sort.SearchFloat64s(values, n)) / float64(len(values))
+	}
+	var f FormatFunc
+	var best float64
+	found := false
+	for _, r := range rs.rules {
+		match, closer := n >= r.Min, r.Min >= best
+		if rs.desc {
+			match, closer = n <= r.Min, r.Min <= best
+		}
+		if match && (!found || closer) {
+			f, best, found = r.Format, r.Min, true
+		}
+	}
+	return f
+}
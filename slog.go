@@ -0,0 +1,25 @@
+//go:build go1.21
+// +build go1.21
+
+package table
+
+import (
+	"log/slog"
+	"strconv"
+)
+
+// LogValue implements slog.LogValuer, so that small tables can be embedded in structured logs.
+// The table is logged as a group holding one group per printed row, keyed by row position,
+// with the values of the row keyed by column header.
+func (t *Table) LogValue() slog.Value {
+	v := t.view()
+	rows := make([]slog.Attr, len(v.cells))
+	for j, cells := range v.cells {
+		attrs := make([]slog.Attr, len(cells))
+		for k, c := range cells {
+			attrs[k] = slog.String(v.headers[k], c)
+		}
+		rows[j] = slog.Attr{Key: strconv.Itoa(j), Value: slog.GroupValue(attrs...)}
+	}
+	return slog.GroupValue(rows...)
+}
@@ -0,0 +1,32 @@
+//go:build go1.21
+// +build go1.21
+
+package table_test
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/jayloop/table"
+)
+
+func TestLogValue(t *testing.T) {
+	tbl := table.New("name", "size")
+	tbl.Row("a", 1)
+	tbl.Row("b", 2)
+	var b strings.Builder
+	logger := slog.New(slog.NewTextHandler(&b, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("status", "table", tbl)
+	want := "level=INFO msg=status table.0.name=a table.0.size=1 table.1.name=b table.1.size=2\n"
+	if got := b.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
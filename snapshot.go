@@ -0,0 +1,169 @@
+package table
+
+import "reflect"
+
+// A Snapshot holds a copy of the rows and configuration of a table.
+// It is created by Table.Snapshot and applied with Table.Restore.
+type Snapshot struct {
+	t Table
+}
+
+// Snapshot captures the current rows and configuration of the table.
+// Cell values are shared rather than copied, so taking a snapshot is cheap.
+func (t *Table) Snapshot() *Snapshot {
+	return &Snapshot{t: t.clone()}
+}
+
+// Restore resets the table to the state captured by s.
+// A snapshot may be restored any number of times.
+func (t *Table) Restore(s *Snapshot) {
+	t.saveTracked()
+	t.saveHistory()
+	*t = s.t.clone()
+}
+
+// clone returns a copy of t not sharing any mutable state with it.
+func (t *Table) clone() Table {
+	c := *t
+	c.headers = append([]string(nil), t.headers...)
+	c.rows = append([]row(nil), t.rows...)
+	// the spare room is meant for the rows of t only
+	c.spareCells, c.spareValues = nil, nil
+	c.maxWidths = append([]int(nil), t.maxWidths...)
+	c.align = append([]Alignment(nil), t.align...)
+	c.footers = append([][]string(nil), t.footers...)
+	c.kinds = append([]kind(nil), t.kinds...)
+	c.minWidths = append([]int(nil), t.minWidths...)
+	c.truncators = append([]TruncateFunc(nil), t.truncators...)
+	c.precision = append([]int(nil), t.precision...)
+	c.format = append([]FormatFunc(nil), t.format...)
+	c.styleCols = append([]*styled(nil), t.styleCols...)
+	c.styleRows = make(map[int]*styled, len(t.styleRows))
+	for k, v := range t.styleRows {
+		c.styleRows[k] = v
+	}
+	c.formatRow = copyFormatMap(t.formatRow)
+	c.formatNotZero = copyFormatMap(t.formatNotZero)
+	c.parsers = make(map[int]ParseFunc, len(t.parsers))
+	for k, v := range t.parsers {
+		c.parsers[k] = v
+	}
+	c.formatSource = make(map[string]FormatFunc, len(t.formatSource))
+	for k, v := range t.formatSource {
+		c.formatSource[k] = v
+	}
+	c.rules = make(map[int]ruleSet, len(t.rules))
+	for k, v := range t.rules {
+		c.rules[k] = v
+	}
+	c.ranks = make(map[int][]float64)
+	c.formatSign = make(map[int]signFormat, len(t.formatSign))
+	for k, v := range t.formatSign {
+		c.formatSign[k] = v
+	}
+	c.nulls = make(map[int]nullStyle, len(t.nulls))
+	for k, v := range t.nulls {
+		c.nulls[k] = v
+	}
+	c.anonymize = make(map[int]anonymizer, len(t.anonymize))
+	for k, v := range t.anonymize {
+		c.anonymize[k] = v
+	}
+	c.convert = make(map[int]converter, len(t.convert))
+	for k, v := range t.convert {
+		c.convert[k] = v
+	}
+	c.locales = make(map[int]Locale, len(t.locales))
+	for k, v := range t.locales {
+		c.locales[k] = v
+	}
+	c.sortKeys = make(map[int]func(string) interface{}, len(t.sortKeys))
+	for k, v := range t.sortKeys {
+		c.sortKeys[k] = v
+	}
+	c.merge = append([]bool(nil), t.merge...)
+	c.autoPrecision = append([]bool(nil), t.autoPrecision...)
+	c.relative = append([]bool(nil), t.relative...)
+	c.alignDecimal = append([]bool(nil), t.alignDecimal...)
+	c.wrap = append([]bool(nil), t.wrap...)
+	c.zeroPolicy = append([]ZeroPolicy(nil), t.zeroPolicy...)
+	c.sortModes = append([]SortMode(nil), t.sortModes...)
+	c.computed = make(map[int]computed, len(t.computed))
+	for k, v := range t.computed {
+		c.computed[k] = v
+	}
+	if t.intern != nil {
+		c.intern = make(map[string]string, len(t.intern))
+		for k, v := range t.intern {
+			c.intern[k] = v
+		}
+	}
+	if t.cache != nil {
+		c.cache = make(map[cellKey]string)
+	}
+	c.sortBy = append([]int(nil), t.sortBy...)
+	c.presorted = append([]int(nil), t.presorted...)
+	c.zeroKeys = append([]int(nil), t.zeroKeys...)
+	c.groupBy = append([]int(nil), t.groupBy...)
+	c.placeholders = append([]string(nil), t.placeholders...)
+	return c
+}
+
+func copyFormatMap(m map[int]FormatFunc) map[int]FormatFunc {
+	c := make(map[int]FormatFunc, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// Rows may share their cells, values and the maps of their cells with a snapshot, so they are
+// never updated in place but replaced by updated copies, using the functions below.
+
+// withString returns a copy of m with key set to s, or removed if s is empty.
+func withString(m map[int]string, key int, s string) map[int]string {
+	c := make(map[int]string, len(m)+1)
+	for k, v := range m {
+		c[k] = v
+	}
+	if s == "" {
+		delete(c, key)
+	} else {
+		c[key] = s
+	}
+	return c
+}
+
+// withMeta returns a copy of m with key set to meta.
+func withMeta(m map[int]Meta, key int, meta Meta) map[int]Meta {
+	c := make(map[int]Meta, len(m)+1)
+	for k, v := range m {
+		c[k] = v
+	}
+	c[key] = meta
+	return c
+}
+
+// setCell sets column i of row r, out of n columns, to value v printed as s. The cells and
+// values of r are only copied if they change.
+func (r *row) setCell(n, i int, v interface{}, s string) {
+	if i < len(r.cells) && r.cells[i] == s && sameValue(r.value(i), v) {
+		return
+	}
+	cells := make([]string, n)
+	copy(cells, r.cells)
+	values := make([]interface{}, n)
+	copy(values, r.values)
+	cells[i], values[i] = s, v
+	r.cells, r.values = cells, values
+}
+
+// sameValue reports whether a and b are equal values of the same type, without panicking on
+// values that can't be compared.
+func sameValue(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	ta := reflect.TypeOf(a)
+	return ta == reflect.TypeOf(b) && ta.Comparable() && a == b
+}
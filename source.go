@@ -0,0 +1,125 @@
+package table
+
+// Append adds the rows of other to the table, in their current order, labeled with source,
+// like the name of the cluster they were listed from. Values are matched to the columns of the
+// table by header; columns of other not in the table are dropped. Row and cell metadata,
+// annotations, links, separators and blank rows are kept, while the removed rows printed by
+// TrackRows are not appended. The label can be shown with SourceColumn and used with FormatSource.
+func (t *Table) Append(other *Table, source string) {
+	other.compute()
+	cols := make([]int, other.columns)
+	for i, h := range other.headers {
+		cols[i] = -1
+		for k, th := range t.headers {
+			if th == h {
+				cols[i] = k
+				break
+			}
+		}
+	}
+	t.dropRemoved()
+	for _, o := range other.rows {
+		if o.removed {
+			continue
+		}
+		r := row{
+			cells:  make([]string, t.columns),
+			values: make([]interface{}, t.columns),
+			index:  len(t.rows),
+			sep:    o.sep,
+			blank:  o.blank,
+			meta:   o.meta,
+			source: source,
+		}
+		for i, k := range cols {
+			if k < 0 || i >= len(o.cells) {
+				continue
+			}
+			r.cells[k] = t.interned(o.cells[i])
+			r.values[k] = o.value(i)
+			if kind := kindOf(r.values[k]); kind > t.kinds[k] {
+				t.kinds[k] = kind
+			}
+			if m, ok := o.cellMeta[i]; ok {
+				r.cellMeta = withMeta(r.cellMeta, k, m)
+			}
+			if a, ok := o.annotations[i]; ok {
+				r.annotations = withString(r.annotations, k, a)
+			}
+			if l, ok := o.links[i]; ok {
+				r.links = withString(r.links, k, l)
+			}
+		}
+		t.rows = append(t.rows, r)
+	}
+}
+
+// SourceColumn adds a column with the given header showing the source label of each row,
+// as set by Append, and returns its index, so that rows can be sorted and grouped by source.
+func (t *Table) SourceColumn(header string) int {
+	i := t.addColumn(header)
+	t.computed[i] = computed{fn: func(rows []row) []interface{} {
+		values := make([]interface{}, len(rows))
+		for j := range rows {
+			values[j] = rows[j].source
+		}
+		return values
+	}}
+	return i
+}
+
+// RowSource returns the source label of the row at index row, as set by Append.
+// Use row index -1 to denote the last row.
+func (t *Table) RowSource(row int) string {
+	if r := t.rowAt(row); r != nil {
+		return r.source
+	}
+	return ""
+}
+
+// FormatSource adds a format function for the rows appended from the listed sources.
+// Formats set by FormatRows take precedence.
+func (t *Table) FormatSource(fn FormatFunc, sources ...string) {
+	t.invalidate()
+	for _, s := range sources {
+		t.formatSource[s] = fn
+	}
+}
+
+// Stack returns a new table with the rows of all tables, in order. Its columns are the union of
+// the headers of the tables, in order of first appearance, and cells of columns missing from
+// a table are left empty. ColumnOrigins reports which tables each column came from.
+func Stack(tables ...*Table) *Table {
+	var headers []string
+	var origins [][]int
+	cols := make(map[string]int)
+	for n, o := range tables {
+		for _, h := range o.headers {
+			i, ok := cols[h]
+			if !ok {
+				i = len(headers)
+				cols[h] = i
+				headers = append(headers, h)
+				origins = append(origins, nil)
+			}
+			if k := len(origins[i]); k == 0 || origins[i][k-1] != n {
+				origins[i] = append(origins[i], n)
+			}
+		}
+	}
+	t := New(headers...)
+	for _, o := range tables {
+		t.Append(o, "")
+	}
+	t.origins = origins
+	return t
+}
+
+// ColumnOrigins returns the positions in the arguments of Stack of the tables having column col,
+// or nil if the table was not created by Stack.
+func (t *Table) ColumnOrigins(col int) []int {
+	if col < 0 || col >= len(t.origins) {
+		return nil
+	}
+	return t.origins[col]
+}
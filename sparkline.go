@@ -0,0 +1,95 @@
+package table
+
+import (
+	"math"
+	"strings"
+)
+
+// sparks are the characters of a sparkline, from the lowest value to the highest.
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// history holds the recent values of a column for each row key, as recorded by Sparkline.
+// It is shared by the snapshots of a table, so that it is kept by Restore.
+type history struct {
+	col, key, n int
+	values      map[string][]float64
+}
+
+// Sparkline adds a column with the given header showing the last n numeric values of column col
+// for each row as a sparkline, like "▁▃▅█", for tables rebuilt and printed repeatedly. As with
+// TrackRows, rows are identified by their cell in column key, and the values are recorded when
+// Restore is called, so call Sparkline before taking the snapshot restored between refreshes.
+// It returns the index of the new column.
+func (t *Table) Sparkline(col, key int, header string, n int) int {
+	if !t.validCol(key) {
+		return t.addComputed(col, header, computed{fn: noValues})
+	}
+	if n < 1 {
+		n = 1
+	}
+	h := &history{col: col, key: key, n: n, values: make(map[string][]float64)}
+	t.histories = append(t.histories, h)
+	return t.addComputed(col, header, computed{
+		fn: func(rows []row) []interface{} {
+			values := make([]interface{}, len(rows))
+			for j := range rows {
+				if rows[j].blank {
+					continue
+				}
+				hist := h.values[rows[j].cell(key)]
+				if f, ok := toFloat(rows[j].value(col)); ok {
+					hist = append(hist[:len(hist):len(hist)], f)
+				}
+				if len(hist) > 0 {
+					values[j] = sparkline(hist)
+				}
+			}
+			return values
+		},
+	})
+}
+
+// saveHistory records the values of the columns with a sparkline, dropping rows that are gone.
+func (t *Table) saveHistory() {
+	for _, h := range t.histories {
+		values := make(map[string][]float64, len(t.rows))
+		for _, r := range t.rows {
+			if r.blank || r.removed {
+				continue
+			}
+			f, ok := toFloat(r.value(h.col))
+			if !ok {
+				continue
+			}
+			k := r.cell(h.key)
+			hist := append(h.values[k], f)
+			if len(hist) > h.n-1 {
+				hist = hist[len(hist)-(h.n-1):]
+			}
+			values[k] = hist
+		}
+		h.values = values
+	}
+}
+
+// sparkline renders values as a line of sparks scaled between their minimum and maximum.
+func sparkline(values []float64) string {
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	var b strings.Builder
+	for _, v := range values {
+		i := len(sparks) / 2
+		if hi > lo {
+			i = int(math.Round((v - lo) / (hi - lo) * float64(len(sparks)-1)))
+		}
+		b.WriteRune(sparks[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package table
+
+import "strings"
+
+// Position is where a status line is printed.
+type Position int
+
+// Status line positions
+const (
+	// Top prints the status line above the header.
+	Top Position = iota
+	// Bottom prints the status line below the last row.
+	Bottom
+)
+
+// status is a line spanning the full table width, with left, centered and right aligned segments.
+type status struct {
+	pos                 Position
+	left, center, right string
+}
+
+// StatusLine adds a line spanning the full width of the table, with left, center and right
+// segments, like a timestamp, a title and a row count. Empty segments are skipped.
+// If the segments don't fit the table width, they are separated by single spaces.
+// StatusLine is only printed by Print, not by PrintHeader or PrintRows.
+func (t *Table) StatusLine(pos Position, left, center, right string) {
+	t.status = &status{pos: pos, left: left, center: center, right: right}
+}
+
+// printStatus prints the status line if it is set at position pos.
+func (t *Table) printStatus(p *printer, v *view, pos Position) {
+	s := t.status
+	if s == nil || s.pos != pos {
+		return
+	}
+	p.writeString(s.line(t.width(v)))
+	p.endLine()
+}
+
+// line returns the status line laid out for a table w characters wide.
+func (s *status) line(w int) string {
+	var segs []string
+	for _, seg := range []string{s.left, s.center, s.right} {
+		if seg != "" {
+			segs = append(segs, seg)
+		}
+	}
+	l, c, r := stringWidth(s.left), stringWidth(s.center), stringWidth(s.right)
+	if l+c+r+len(segs)-1 > w {
+		return strings.Join(segs, " ")
+	}
+	// center the middle segment, keeping at least one space to the other segments
+	lo, hi := l, w-r-c
+	if l > 0 {
+		lo++
+	}
+	if r > 0 {
+		hi--
+	}
+	start := (w - c) / 2
+	if start < lo {
+		start = lo
+	} else if start > hi {
+		start = hi
+	}
+	b := []byte(s.left)
+	if c > 0 {
+		b = appendWhitespace(b, start-l)
+		b = append(b, s.center...)
+		l = start + c
+	}
+	if r > 0 {
+		b = appendWhitespace(b, w-r-l)
+		b = append(b, s.right...)
+	}
+	return string(b)
+}
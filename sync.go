@@ -0,0 +1,47 @@
+package table
+
+import (
+	"io"
+	"sync"
+)
+
+// NewSync creates a new table like New, for a collector adding rows while a reporter prints them.
+// Row, BlankRow, Separator and Footer may then be called concurrently with PrintSnapshot.
+// Other methods must still not be called concurrently, so set all options before sharing the table.
+func NewSync(headers ...string) *Table {
+	t := New(headers...)
+	t.lock = new(sync.Mutex)
+	return t
+}
+
+// PrintSnapshot prints a copy of the current rows of the table as by Print. For tables created
+// by NewSync the rows are copied under a lock, which is released before printing, so that rows
+// can keep being added meanwhile and each print shows a consistent view. Rows tracked by
+// TrackRows are compared as by Print, on the copy.
+func (t *Table) PrintSnapshot(out io.Writer) error {
+	t.lockRows()
+	c := t.clone()
+	if tr := t.tracker; tr != nil {
+		// the copy marks its own rows as added or removed while printing
+		cp := *tr
+		cp.prev = append([]row(nil), tr.prev...)
+		c.tracker = &cp
+		tr.printed = true
+	}
+	t.unlockRows()
+	return c.Print(out)
+}
+
+// lockRows locks the rows of tables created by NewSync.
+func (t *Table) lockRows() {
+	if t.lock != nil {
+		t.lock.Lock()
+	}
+}
+
+// unlockRows unlocks the rows of tables created by NewSync.
+func (t *Table) unlockRows() {
+	if t.lock != nil {
+		t.lock.Unlock()
+	}
+}
@@ -3,16 +3,17 @@ package table
 
 import (
 	"fmt"
-	"io"
 	"reflect"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
 var (
 	defaultHeaderFormat FormatFunc
+	strict              bool
 	mu                  sync.RWMutex
 )
 
@@ -23,26 +24,162 @@ func DefaultHeaderFormat(f FormatFunc) {
 	mu.Unlock()
 }
 
+// Strict enables or disables strict mode for all tables. In strict mode, methods given a column index
+// out of range panic instead of ignoring it, so that mistakes in column numbers are caught during development.
+func Strict(enabled bool) {
+	mu.Lock()
+	strict = enabled
+	mu.Unlock()
+}
+
+// validCol reports whether col is a valid column index, panicking in strict mode if it is not.
+func (t *Table) validCol(col int) bool {
+	if col >= 0 && col < t.columns {
+		return true
+	}
+	mu.RLock()
+	s := strict
+	mu.RUnlock()
+	if s {
+		panic(fmt.Sprintf("table: column index %d out of range for table with %d columns", col, t.columns))
+	}
+	return false
+}
+
+// validCols checks cols with validCol and returns those in range, for methods that ignore
+// out of range column indexes.
+func (t *Table) validCols(cols []int) []int {
+	valid := make([]int, 0, len(cols))
+	for _, col := range cols {
+		if t.validCol(col) {
+			valid = append(valid, col)
+		}
+	}
+	return valid
+}
+
 // FormatFunc is a user defined function applying formatting to a header or row value.
 // The typical usecase is setting colors by adding escape characters.
-// A format function should not change the printed length of the value.
+// A format function may also change the printed length of the value, for example by adding an icon;
+// column widths are measured after formatting, not counting escape sequences.
 type FormatFunc func(string) string
 
 // A Table record stores all table data and formatting options.
-// It is not safe for concurrent use.
+// It is not safe for concurrent use, except as described by NewSync.
 type Table struct {
 	columns       int
 	headers       []string
-	rows          [][]string
-	widths        []int
+	rows          []row
 	maxWidths     []int
+	align         []Alignment
+	kinds         []kind
+	minWidths     []int
+	truncators    []TruncateFunc
 	precision     []int
 	padding       int
 	format        []FormatFunc
 	formatHeader  FormatFunc
+	formatBorder  FormatFunc
+	styleHeader   *styled
+	styleCols     []*styled
+	styleRows     map[int]*styled
 	formatRow     map[int]FormatFunc
 	formatNotZero map[int]FormatFunc
+	formatSign    map[int]signFormat
+	nulls         map[int]nullStyle
+	merge         []bool
+	anonymize     map[int]anonymizer
+	sortKeys      map[int]func(string) interface{}
+	sortModes     []SortMode
+	convert       map[int]converter
+	locales       map[int]Locale // set by UseLocale
+	autoPrecision []bool
+	relative      []bool // times printed relative to the time of printing, set by AutoFormat
+	alignDecimal  []bool
+	wrap          []bool
+	zeroPolicy    []ZeroPolicy
+	rules         map[int]ruleSet
+	ranks         map[int][]float64
+	computed      map[int]computed
 	sortBy        []int
+	presorted     []int // set by Sorted
+	reordered     bool  // sorted since the rows were added
+	indexHeader   string
+	hideZero      bool
+	zeroKeys      []int
+	collapse      bool
+	compact       bool
+	status        *status
+	border        BorderStyle
+	profile       Profile
+	formatSource  map[string]FormatFunc
+	abbreviations map[string]string
+	footers       [][]string
+	origins       [][]int
+	title         string
+	titleAlign    Alignment
+	titleFormat   FormatFunc
+	titleWrap     bool
+	headerRule    rune
+	parsers       map[int]ParseFunc
+	stripes       [2]FormatFunc
+	separateEvery int
+	autoFit       bool
+	placeholders  []string
+	hideConstant  bool
+	constCaption  bool
+	totalMaxWidth int
+	frozen        int // leading columns repeated by FreezeColumns
+	clipWidth     int
+	groupBy       []int
+	groupGap      Gap
+	eol           string
+	noFinalEOL    bool
+	spareCells    []string      // preallocated by Grow
+	spareValues   []interface{} // preallocated by Grow
+	intern        map[string]string
+	cache         map[cellKey]string
+	colorLevel    ColorLevel
+	onRowPrinted  func(i int, line string)
+	onDiagnostics func(Diagnostics)
+	progress      func(done, total int)
+	progressMin   int
+	trailing      Trailing
+	tracker       *tracker    // shared by snapshots
+	lock          *sync.Mutex // set by NewSync, shared by snapshots
+	histories     []*history  // shared by snapshots
+}
+
+// row is a single table row as added by Row.
+type row struct {
+	cells    []string
+	values   []interface{} // values as passed to Row
+	index    int           // insertion index
+	sep      bool          // print a separator line below the row
+	blank    bool          // added by BlankRow
+	meta     Meta
+	cellMeta map[int]Meta
+	source   string // label set by Append
+	removed  bool   // appended by TrackRows for a row gone since the last refresh
+	// annotations set by Annotate and links set by SetLink, by column
+	annotations map[int]string
+	links       map[int]string
+}
+
+// cell returns the value of column i, or an empty string if the row is short.
+func (r *row) cell(i int) string {
+	if i >= 0 && i < len(r.cells) {
+		return r.cells[i]
+	}
+	return ""
+}
+
+// value returns the original value of column i, or nil if the row is short.
+func (r *row) value(i int) interface{} {
+	if i >= 0 && i < len(r.values) {
+		return r.values[i]
+	}
+	return nil
 }
 
 // New creates a new table with the given headers.
@@ -52,18 +189,37 @@ func New(headers ...string) *Table {
 	t := &Table{
 		columns:       l,
 		headers:       headers,
-		widths:        make([]int, l),
 		maxWidths:     make([]int, l),
+		align:         make([]Alignment, l),
+		kinds:         make([]kind, l),
+		truncators:    make([]TruncateFunc, l),
 		precision:     make([]int, l),
 		format:        make([]FormatFunc, l),
 		formatRow:     make(map[int]FormatFunc),
 		formatNotZero: make(map[int]FormatFunc),
-		rows:          [][]string{},
+		formatSign:    make(map[int]signFormat),
+		nulls:         make(map[int]nullStyle),
+		styleCols:     make([]*styled, l),
+		styleRows:     make(map[int]*styled),
+		merge:         make([]bool, l),
+		anonymize:     make(map[int]anonymizer),
+		sortKeys:      make(map[int]func(string) interface{}),
+		sortModes:     make([]SortMode, l),
+		convert:       make(map[int]converter),
+		locales:       make(map[int]Locale),
+		autoPrecision: make([]bool, l),
+		relative:      make([]bool, l),
+		alignDecimal:  make([]bool, l),
+		wrap:          make([]bool, l),
+		zeroPolicy:    make([]ZeroPolicy, l),
+		rules:         make(map[int]ruleSet),
+		ranks:         make(map[int][]float64),
+		formatSource:  make(map[string]FormatFunc),
+		parsers:       make(map[int]ParseFunc),
+		computed:      make(map[int]computed),
+		rows:          []row{},
 		padding:       2,
 	}
-	for i, h := range headers {
-		t.widths[i] = len([]rune(h))
-	}
 	mu.RLock()
 	if defaultHeaderFormat != nil {
 		t.formatHeader = defaultHeaderFormat
@@ -72,6 +228,27 @@ func New(headers ...string) *Table {
 	return t
 }
 
+// addColumn adds a column with the given header to the table and returns its index.
+func (t *Table) addColumn(header string) int {
+	t.columns++
+	t.headers = append(t.headers, header)
+	t.maxWidths = append(t.maxWidths, 0)
+	t.align = append(t.align, 0)
+	t.kinds = append(t.kinds, kindUnknown)
+	t.truncators = append(t.truncators, nil)
+	t.precision = append(t.precision, 0)
+	t.format = append(t.format, nil)
+	t.styleCols = append(t.styleCols, nil)
+	t.merge = append(t.merge, false)
+	t.autoPrecision = append(t.autoPrecision, false)
+	t.relative = append(t.relative, false)
+	t.alignDecimal = append(t.alignDecimal, false)
+	t.wrap = append(t.wrap, false)
+	t.zeroPolicy = append(t.zeroPolicy, ZeroShow)
+	t.sortModes = append(t.sortModes, SortText)
+	return t.columns - 1
+}
+
 // AddStruct adds 2-column rows to a table by iterating over struct fields.
 // The table is created by a previous call to New:
 //  table.New("key", "value")
@@ -90,6 +267,93 @@ func (t *Table) FormatHeader(fn FormatFunc) {
 	t.formatHeader = fn
 }
 
+// LineEnding sets the line ending used when printing, for example "\r\n" for output consumed on Windows.
+// The default is "\n".
+func (t *Table) LineEnding(eol string) {
+	t.eol = eol
+}
+
+// FinalNewline sets whether the last printed line is terminated by a line ending. The default is true.
+func (t *Table) FinalNewline(enabled bool) {
+	t.noFinalEOL = !enabled
+}
+
+// StyleHeader sets the style of the column headers. A format set by FormatHeader takes precedence.
+func (t *Table) StyleHeader(s Style) {
+	t.styleHeader = newStyled(s)
+}
+
+// StyleCols sets the style of the listed column indexes. Formats set by FormatCols take precedence.
+func (t *Table) StyleCols(s Style, cols ...int) {
+	t.invalidate()
+	for _, col := range cols {
+		if t.validCol(col) {
+			t.styleCols[col] = newStyled(s)
+		}
+	}
+}
+
+// StyleRows sets the style of the listed row indexes. Use row index -1 to denote the last row.
+// Formats set by FormatRows take precedence.
+func (t *Table) StyleRows(s Style, rows ...int) {
+	t.invalidate()
+	for _, row := range rows {
+		if row == -1 {
+			row = len(t.rows) - 1
+		}
+		t.styleRows[row] = newStyled(s)
+	}
+}
+
+// OnRowPrinted sets a function called with each row line once it is written, without the line ending.
+// Rows printed on several lines, like multi-line or wrapped values, call it once per line.
+// The row index i is the position of the row in the table, as used by FormatRows.
+// It can be used to collect the rendered lines, report progress or mirror the output elsewhere.
+func (t *Table) OnRowPrinted(fn func(i int, line string)) {
+	t.onRowPrinted = fn
+}
+
+// Progress sets a function reporting the number of rows printed so far, for tables of at least
+// minRows rows. It is called about every percent of the rows and once all are printed,
+// so that command line tools can show progress while writing large tables.
+func (t *Table) Progress(minRows int, fn func(done, total int)) {
+	t.progressMin = minRows
+	t.progress = fn
+}
+
+// Trailing controls the whitespace at the end of printed lines.
+type Trailing int
+
+// Trailing whitespace modes
+const (
+	// TrailingDefault leaves the last column unpadded. Lines still end in whitespace when
+	// their last cells are empty.
+	TrailingDefault Trailing = iota
+	// TrailingTrim removes all whitespace at the end of lines, for diff friendly output.
+	TrailingTrim
+	// TrailingPad pads the last column to its full width, so all lines have the same length,
+	// for fixed width parsers.
+	TrailingPad
+)
+
+// TrailingSpace sets how whitespace at the end of printed lines is handled.
+func (t *Table) TrailingSpace(mode Trailing) {
+	t.trailing = mode
+}
+
+// HeaderRule sets a character repeated under each header, across the width of its column,
+// like "----  ----". Use 0, the default, for no line. The line is formatted by FormatBorder.
+// It is not printed with Borders, which always draw a line below the header.
+func (t *Table) HeaderRule(r rune) {
+	t.headerRule = r
+}
+
+// FormatBorder sets the format applied to separator lines and borders when printing,
+// so that they can be styled apart from the cell values.
+func (t *Table) FormatBorder(fn FormatFunc) {
+	t.formatBorder = fn
+}
+
 // Padding sets the number of whitespaces added as padding between columns.
 func (t *Table) Padding(p int) {
 	t.padding = p
@@ -98,25 +362,137 @@ func (t *Table) Padding(p int) {
 // MaxWidth sets the max width in characters for the listed column indexes.
 func (t *Table) MaxWidth(chars int, cols ...int) {
 	for _, col := range cols {
-		if col >= 0 && col < t.columns {
+		if t.validCol(col) {
 			t.maxWidths[col] = chars
 		}
 	}
 }
 
+// Wrap sets the listed columns to wrap cells wider than the column, as limited by MaxWidth or
+// TotalMaxWidth, onto continuation lines at spaces instead of truncating them. The other columns
+// of the row are left empty on the continuation lines.
+func (t *Table) Wrap(cols ...int) {
+	for _, col := range cols {
+		if t.validCol(col) {
+			t.wrap[col] = true
+		}
+	}
+}
+
+// Alignment is the horizontal alignment of values within a column.
+type Alignment int
+
+// Alignments
+const (
+	Left Alignment = iota + 1
+	Right
+	Center
+)
+
+// Align sets the alignment of the values and headers of the listed columns. By default, columns
+// where all added values are numbers are aligned Right and all other columns Left.
+func (t *Table) Align(a Alignment, cols ...int) {
+	for _, col := range cols {
+		if t.validCol(col) {
+			t.align[col] = a
+		}
+	}
+}
+
+// A TruncateFunc shortens a value to at most width characters.
+type TruncateFunc func(value string, width int) string
+
+// Truncator sets the function used to shorten values of the listed columns that are wider than the column,
+// replacing the default of cutting the end and adding "...". It allows domain specific shortening,
+// like keeping both ends of a hash or abbreviating a namespace.
+func (t *Table) Truncator(fn TruncateFunc, cols ...int) {
+	for _, col := range cols {
+		if t.validCol(col) {
+			t.truncators[col] = fn
+		}
+	}
+}
+
+// HeaderAbbreviations sets short forms of headers, like "NAMESPACE" to "NS", used when the table
+// is wider than set by TotalMaxWidth. Headers are abbreviated before any values are truncated,
+// starting with the ones saving the most characters, until the table fits.
+func (t *Table) HeaderAbbreviations(abbr map[string]string) {
+	t.abbreviations = abbr
+}
+
+// TotalMaxWidth sets the max width in characters of the whole table, including padding.
+// When the table is wider, the widest columns are truncated first, so that the budget is shared
+// between all columns rather than set for each of them with MaxWidth.
+func (t *Table) TotalMaxWidth(chars int) {
+	t.totalMaxWidth = chars
+}
+
+// ClipWidth cuts every printed line, including titles, status lines and notes, to at most chars
+// characters after rendering, keeping escape sequences intact. It guarantees that no line wraps
+// on dumb terminals or fixed width log sinks whatever the content, while TotalMaxWidth fits the
+// columns to a width. Use 0, the default, for no limit. It does not apply to other formats than text.
+func (t *Table) ClipWidth(chars int) {
+	t.clipWidth = chars
+}
+
 // Precision sets the number of digits to include when printing float values.
 // It must be set before adding the rows.
 func (t *Table) Precision(digits int, cols ...int) {
 	for _, col := range cols {
-		if col >= 0 && col < t.columns {
+		if t.validCol(col) {
 			t.precision[col] = digits
 		}
 	}
 }
 
+// AutoPrecision sets the listed columns to print all float values with the largest number of digits
+// actually present in the column, so that decimals align without choosing a fixed precision.
+// If a precision is also set for a column, it is used as the upper limit.
+func (t *Table) AutoPrecision(cols ...int) {
+	for _, col := range cols {
+		if t.validCol(col) {
+			t.autoPrecision[col] = true
+		}
+	}
+}
+
+// AlignDecimal sets the listed columns to line up numbers on their decimal point, so that
+// 0.001 and 123.45 are printed as "  0.001" and "123.45 ". Other values are aligned as usual.
+func (t *Table) AlignDecimal(cols ...int) {
+	for _, col := range cols {
+		if t.validCol(col) {
+			t.alignDecimal[col] = true
+		}
+	}
+}
+
+// decimals returns the largest number of digits after the decimal point of any float value in column i.
+func (t *Table) decimals(i int) int {
+	d := 0
+	for j := range t.rows {
+		var s string
+		switch v := t.rows[j].value(i).(type) {
+		case float32:
+			s = strconv.FormatFloat(float64(v), 'f', -1, 32)
+		case float64:
+			s = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			continue
+		}
+		if dot := strings.IndexByte(s, '.'); dot >= 0 {
+			d = max(d, len(s)-dot-1)
+		}
+	}
+	if p := t.precision[i]; p > 0 && d > p {
+		d = p
+	}
+	return d
+}
+
 // FormatRows adds a format function for the listed rows indexes.
 // Use row index -1 to denote the last row.
 func (t *Table) FormatRows(fn FormatFunc, rows ...int) {
+	t.invalidate()
 	for _, row := range rows {
 		if row == -1 {
 			//last row
@@ -128,22 +504,365 @@ func (t *Table) FormatRows(fn FormatFunc, rows ...int) {
 
 // FormatCols adds a format function for the listed column indexes.
 func (t *Table) FormatCols(fn FormatFunc, cols ...int) {
+	t.invalidate()
 	for _, col := range cols {
-		if col >= 0 && col < t.columns {
+		if t.validCol(col) {
 			t.format[col] = fn
 		}
 	}
 }
 
+// StripeRows sets formats applied to alternating printed rows, starting with even for the first row,
+// to make wide tables easier to follow. Either function may be nil. Stripes follow the rows as
+// printed, after sorting and hiding, and only apply to cells without any other format.
+func (t *Table) StripeRows(even, odd FormatFunc) {
+	t.invalidate()
+	t.stripes = [2]FormatFunc{even, odd}
+}
+
 // FormatNotZero adds a format function applied on values != "0"
 func (t *Table) FormatNotZero(fn FormatFunc, cols ...int) {
+	t.invalidate()
 	for _, col := range cols {
-		if col >= 0 && col < t.columns {
+		if t.validCol(col) {
 			t.formatNotZero[col] = fn
 		}
 	}
 }
 
+// ZeroPolicy is how zero values of a column are printed.
+type ZeroPolicy int
+
+// Zero policies
+const (
+	// ZeroShow prints zeros as any other value.
+	ZeroShow ZeroPolicy = iota
+	// ZeroHide prints zeros as empty cells.
+	ZeroHide
+	// ZeroDash prints zeros as "-".
+	ZeroDash
+	// ZeroDim prints zeros in faint text.
+	ZeroDim
+)
+
+// faint is the format applied to zeros by ZeroDim.
+var faint = Format(Faint)
+
+// ZeroValue sets how zero values of the listed columns are printed, which makes sparse counter
+// tables easier to read. Zero values are numbers equal to zero, like "0" and "0.00".
+func (t *Table) ZeroValue(policy ZeroPolicy, cols ...int) {
+	t.invalidate()
+	for _, col := range cols {
+		if t.validCol(col) {
+			t.zeroPolicy[col] = policy
+		}
+	}
+}
+
+// isZero reports whether s is a number equal to zero.
+func isZero(s string) bool {
+	f, ok := toFloat(strings.TrimSpace(s))
+	return ok && f == 0
+}
+
+// signFormat holds the format functions applied to positive and negative values.
+type signFormat struct {
+	pos, neg FormatFunc
+}
+
+// FormatSign adds format functions applied on positive and negative numeric values of the listed columns.
+// Either function may be nil. Zero and non-numeric values are not affected.
+func (t *Table) FormatSign(pos, neg FormatFunc, cols ...int) {
+	t.invalidate()
+	for _, col := range cols {
+		if t.validCol(col) {
+			t.formatSign[col] = signFormat{pos: pos, neg: neg}
+		}
+	}
+}
+
+// signFormatFor returns the sign format function of column i applying to value s, if any.
+func (t *Table) signFormatFor(i int, s string) FormatFunc {
+	f, ok := t.formatSign[i]
+	if !ok {
+		return nil
+	}
+	n, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+	switch {
+	case err != nil:
+		return nil
+	case n > 0:
+		return f.pos
+	case n < 0:
+		return f.neg
+	}
+	return nil
+}
+
+// cellFormat returns the format function applying to value s of column i in row j.
+func (t *Table) cellFormat(j, i int, s string) FormatFunc {
+	// cells aligned by AlignDecimal are padded and numbers may be localized, which must not
+	// change the formats picked
+	s = t.locales[i].delocalize(strings.TrimSpace(s))
+	switch {
+	case i == indexCol:
+	case t.trackFormat(j, i) != nil:
+		return t.trackFormat(j, i)
+	case t.nullFormat(j, i) != nil:
+		return t.nullFormat(j, i)
+	case t.zeroPolicy[i] == ZeroDim && isZero(s):
+		return faint
+	case t.formatNotZero[i] != nil && s != "0":
+		return t.formatNotZero[i]
+	case t.ruleFormat(j, i, s) != nil:
+		return t.ruleFormat(j, i, s)
+	case t.signFormatFor(i, s) != nil:
+		return t.signFormatFor(i, s)
+	case t.formatRow[j] != nil:
+		return t.formatRow[j]
+	case t.styleRows[j] != nil:
+		return t.styleRows[j].fn
+	case j >= 0 && j < len(t.rows) && t.formatSource[t.rows[j].source] != nil:
+		return t.formatSource[t.rows[j].source]
+	case t.format[i] != nil:
+		return t.format[i]
+	case t.styleCols[i] != nil:
+		return t.styleCols[i].fn
+	}
+	return nil
+}
+
+// cellKey identifies a formatted cell in the format cache. Besides its position and value, it
+// holds the state of the row the formats depend on, which changes when rows are sorted,
+// appended or tracked.
+type cellKey struct {
+	row, col int
+	odd      bool
+	value    string
+	source   string
+	null     bool
+	track    trackState
+}
+
+// CacheFormat enables or disables caching of formatted cell values between prints.
+// When enabled, format functions are only applied to cells that changed since the last print,
+// which saves work when the same table is printed repeatedly, for example in a watch loop.
+// Format functions must then always return the same output for the same input.
+func (t *Table) CacheFormat(enabled bool) {
+	if enabled {
+		t.cache = make(map[cellKey]string)
+	} else {
+		t.cache = nil
+	}
+}
+
+// invalidate clears the format cache after a change of the formatting options.
+func (t *Table) invalidate() {
+	if t.cache != nil {
+		t.cache = make(map[cellKey]string)
+	}
+}
+
+// formatCell applies the format function of column i in row j, printed at position pos, to s,
+// using the format cache if enabled. Use a negative pos for rows without stripes, like footers.
+// Cells formatted are stored in next, which replaces the cache once the print is done.
+func (t *Table) formatCell(next map[cellKey]string, j, pos, i int, s string) string {
+	key := cellKey{row: j, col: i, odd: pos%2 == 1, value: s, track: t.trackState(j, i)}
+	if j >= 0 && j < len(t.rows) {
+		key.source, key.null = t.rows[j].source, t.rows[j].null(i)
+	}
+	if c, ok := t.cache[key]; ok {
+		next[key] = c
+		return c
+	}
+	f := t.cellFormat(j, i, s)
+	if f == nil && pos >= 0 {
+		f = t.stripes[pos%2]
+	}
+	if f != nil {
+		s = f(s)
+	}
+	if next != nil {
+		next[key] = s
+	}
+	return s
+}
+
+// Merge merges identical consecutive cells vertically for the listed column indexes.
+// A merged cell is printed once, as a single block spanning all of its rows, and neither
+// separators nor group gaps set to GapRule are drawn through it. Blank group gaps end the block.
+// A cell only merges with the one above if all merged columns to its left do as well,
+// so nested groups are kept apart. HTML output spans merged cells with rowspan, and Markdown,
+// which can't, repeats their value on every row.
+func (t *Table) Merge(cols ...int) {
+	for _, col := range cols {
+		if t.validCol(col) {
+			t.merge[col] = true
+		}
+	}
+}
+
+// merged reports whether printed cell k of printed row j is merged with the cell above it.
+func (t *Table) merged(v *view, j, k int) bool {
+	if i := v.src[k]; j == 0 || v.after[j-1] == lineBlank || i == indexCol || !t.merge[i] {
+		return false
+	}
+	for n := 0; n <= k; n++ {
+		if i := v.src[n]; i != indexCol && t.merge[i] && v.cells[j-1][n] != v.cells[j][n] {
+			return false
+		}
+	}
+	return true
+}
+
+// ShowIndex adds a leading column with the given header showing the insertion index of each row.
+// The index is kept when the rows are sorted, so printed rows can be correlated with the order they were added in.
+// An empty header removes the column.
+func (t *Table) ShowIndex(header string) {
+	t.indexHeader = header
+}
+
+// HideZeroRows hides rows where all columns other than the listed key columns are zero or empty.
+// Computed columns are not considered.
+func (t *Table) HideZeroRows(keys ...int) {
+	t.hideZero = true
+	t.zeroKeys = t.validCols(keys)
+}
+
+// zero reports whether r has only zero or empty values outside of the key columns.
+func (t *Table) zero(r *row) bool {
+outer:
+	for i := 0; i < t.columns; i++ {
+		if _, ok := t.computed[i]; ok {
+			continue
+		}
+		for _, k := range t.zeroKeys {
+			if k == i {
+				continue outer
+			}
+		}
+		if c := r.cell(i); c != "" {
+			if f, ok := toFloat(c); !ok || f != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// CollapseEmpty omits columns where every printed row is empty or holds one of the listed placeholder values.
+func (t *Table) CollapseEmpty(placeholders ...string) {
+	t.collapse = true
+	t.placeholders = placeholders
+}
+
+// Compact sets a dense layout for small terminals: columns are separated by a single space,
+// blank lines between groups are dropped and columns holding only empty or placeholder values
+// (see CollapseEmpty) are omitted.
+func (t *Table) Compact(on bool) {
+	t.compact = on
+}
+
+// empty reports whether s is empty or one of the placeholder values set by CollapseEmpty.
+func (t *Table) empty(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, p := range t.placeholders {
+		if s == p {
+			return true
+		}
+	}
+	return false
+}
+
+// HideConstant omits columns where all printed rows share the same value.
+// If caption is set, the omitted values are listed in a line below the table,
+// like "region=eu-west-1 for all rows". Tables with less than two rows are not affected.
+func (t *Table) HideConstant(caption bool) {
+	t.hideConstant = true
+	t.constCaption = caption
+}
+
+// constantColumn reports whether all cells of printed column k are equal.
+func constantColumn(v *view, k int) bool {
+	if len(v.cells) < 2 {
+		return false
+	}
+	for _, cells := range v.cells[1:] {
+		if cells[k] != v.cells[0][k] {
+			return false
+		}
+	}
+	return true
+}
+
+// BlankRow adds an empty row. Blank rows are kept last when sorting and are never hidden,
+// so they can be used to give tables printed side by side or stacked equal heights.
+func (t *Table) BlankRow() {
+	t.lockRows()
+	defer t.unlockRows()
+	t.rows = append(t.rows, row{index: len(t.rows), blank: true})
+}
+
+// PadToRows adds blank rows until the table has at least n rows.
+func (t *Table) PadToRows(n int) {
+	for len(t.rows) < n {
+		t.BlankRow()
+	}
+}
+
+// Separator adds a horizontal line below the last added row, to delimit logical sections of the table.
+// The line stays attached to that row when the table is sorted.
+// Separator has no effect before the first row is added.
+func (t *Table) Separator() {
+	t.lockRows()
+	defer t.unlockRows()
+	if len(t.rows) > 0 {
+		t.rows[len(t.rows)-1].sep = true
+	}
+}
+
+// SeparateEvery adds a separator line after every n printed rows, or after every row if n is 1,
+// which helps reading dense or multi-line content. Use 0, the default, for no lines.
+// No line is printed after the last row.
+func (t *Table) SeparateEvery(n int) {
+	t.separateEvery = n
+}
+
+// Gap is the kind of line printed between groups of rows.
+type Gap int
+
+// Group gaps
+const (
+	// GapBlank separates groups with a blank line.
+	GapBlank Gap = iota
+	// GapRule separates groups with a horizontal line.
+	GapRule
+)
+
+// GroupBy groups consecutive rows sharing the same values in the listed columns,
+// separating the groups as set by GroupGap. Sort the table by the same columns to
+// gather all rows of a group.
+func (t *Table) GroupBy(cols ...int) {
+	t.groupBy = t.validCols(cols)
+}
+
+// GroupGap sets the kind of line printed between groups. The default is GapBlank.
+func (t *Table) GroupGap(g Gap) {
+	t.groupGap = g
+}
+
+// sameGroup reports whether rows a and b belong to the same group.
+func (t *Table) sameGroup(a, b *row) bool {
+	for _, i := range t.groupBy {
+		if a.cell(i) != b.cell(i) {
+			return false
+		}
+	}
+	return true
+}
+
 // Len returns the number of rows
 func (t *Table) Len() int {
 	return len(t.rows)
@@ -151,9 +870,23 @@ func (t *Table) Len() int {
 
 // Less compares row i against row j
 func (t *Table) Less(i, j int) bool {
+	return t.less(i, j, false)
+}
+
+// less reports whether row i sorts before row j, comparing the values passed to Row if typed is set.
+func (t *Table) less(i, j int, typed bool) bool {
+	if a, b := t.rows[i].blank, t.rows[j].blank; a != b {
+		return b
+	}
 	var c int
 	for _, k := range t.sortBy {
-		c = strings.Compare(t.rows[i][k], t.rows[j][k])
+		if key := t.sortKeys[k]; key != nil {
+			c = compareValues(key(t.rows[i].cell(k)), key(t.rows[j].cell(k)))
+		} else if typed || t.sortModes[k] == SortRaw {
+			c = compareValues(t.rows[i].value(k), t.rows[j].value(k))
+		} else {
+			c = strings.Compare(t.rows[i].cell(k), t.rows[j].cell(k))
+		}
 		if c != 0 {
 			break
 		}
@@ -161,6 +894,86 @@ func (t *Table) Less(i, j int) bool {
 	return c < 0
 }
 
+// SortKey sets a function mapping the printed values of column col to the keys used by Sort,
+// so that columns of human formatted values still sort correctly, like sizes printed as "1.2 GiB".
+// Keys may be numbers, strings, booleans, time.Time values or nil, which sorts first.
+func (t *Table) SortKey(col int, key func(v string) interface{}) {
+	if t.validCol(col) {
+		t.sortKeys[col] = key
+	}
+}
+
+// SortMode is what Sort compares the values of a column by.
+type SortMode int
+
+// Sort modes
+const (
+	// SortText compares the printed text of values, so that 10 sorts before 9.
+	SortText SortMode = iota
+	// SortRaw compares the values as passed to Row, so that numbers and times sort by magnitude.
+	SortRaw
+)
+
+// SortUsing sets what Sort compares the values of the listed columns by. The default is SortText,
+// which suits values printed differently from their raw form, like masked or shortened IDs.
+// A key set with SortKey takes precedence.
+func (t *Table) SortUsing(mode SortMode, cols ...int) {
+	for _, col := range cols {
+		if t.validCol(col) {
+			t.sortModes[col] = mode
+		}
+	}
+}
+
+// compareValues compares two sort keys, returning -1, 0 or 1.
+// Keys of different kinds are compared by their string representation.
+func compareValues(a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	_, sa := a.(string)
+	_, sb := b.(string)
+	if x, ok := toFloat(a); ok && !sa {
+		if y, ok := toFloat(b); ok && !sb {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+	switch x := a.(type) {
+	case time.Time:
+		if y, ok := b.(time.Time); ok {
+			switch {
+			case x.Before(y):
+				return -1
+			case x.After(y):
+				return 1
+			}
+			return 0
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			switch {
+			case x == y:
+				return 0
+			case !x:
+				return -1
+			}
+			return 1
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
 // Swap swaps row i and j
 func (t *Table) Swap(i, j int) {
 	t.rows[i], t.rows[j] = t.rows[j], t.rows[i]
@@ -168,126 +981,282 @@ func (t *Table) Swap(i, j int) {
 
 // Sort sort the table rows by the listed columns
 func (t *Table) Sort(cols ...int) {
+	cols = t.validCols(cols)
+	t.compute()
+	t.sortBy = cols
+	if !t.isSorted(cols) {
+		sort.Sort(t)
+		t.reordered = true
+	}
+}
+
+// SortStable sorts the table rows by the listed columns like Sort, but compares the values passed
+// to Row, so that numbers and times are ordered by value, and keeps rows that compare equal in the
+// order they were in. Sort keys set by SortKey are still used.
+func (t *Table) SortStable(cols ...int) {
+	cols = t.validCols(cols)
+	t.compute()
 	t.sortBy = cols
-	sort.Sort(t)
+	if !t.isSorted(cols) {
+		sort.SliceStable(t.rows, func(i, j int) bool {
+			return t.less(i, j, true)
+		})
+		t.reordered = true
+	}
+}
+
+// Sorted marks the rows as already sorted by the listed columns, so that sorting by the same
+// columns with Sort or SortStable is skipped. It saves re-sorting large datasets that are read
+// in order, like the results of a database query. The rows must then be added in that order.
+func (t *Table) Sorted(cols ...int) {
+	t.presorted = t.validCols(cols)
+}
+
+// isSorted reports whether the rows are marked as sorted by cols and still in the order added.
+func (t *Table) isSorted(cols []int) bool {
+	if t.presorted == nil || t.reordered || len(cols) != len(t.presorted) {
+		return false
+	}
+	for i, col := range cols {
+		if t.presorted[i] != col {
+			return false
+		}
+	}
+	return true
+}
+
+// Unsort restores the order the rows were added in, undoing any previous Sort.
+func (t *Table) Unsort() {
+	t.reordered = false
+	t.sortBy = nil
+	sort.Slice(t.rows, func(i, j int) bool {
+		return t.rows[i].index < t.rows[j].index
+	})
 }
 
 // Row adds row data.
+// Values other than strings, numbers and booleans are printed with the %v verb of the fmt package,
+// which prints maps sorted by key, so that repeated prints give the same output.
+// Nil values are NULL, printed as set by NullValue.
 func (t *Table) Row(values ...interface{}) {
+	t.lockRows()
+	defer t.unlockRows()
 	// truncate any overflowing values
 	if len(values) > t.columns {
 		values = values[:t.columns]
 	}
-	row := make([]string, len(values))
+	t.dropRemoved()
+	row := row{
+		cells:  t.allocCells(len(values)),
+		values: append(t.allocValues(len(values)), values...),
+		index:  len(t.rows),
+	}
 	for i, v := range values {
-		p := t.precision[i]
-		if p == 0 {
-			p = 2
-		}
-		var v2 string
-		switch v := v.(type) {
-		case int32:
-			v2 = strconv.Itoa(int(v))
-		case int64:
-			v2 = strconv.FormatInt(v, 10)
-		case uint64:
-			v2 = strconv.FormatUint(v, 10)
-		case float32:
-			v2 = strconv.FormatFloat(float64(v), 'f', p, 32)
-		case float64:
-			v2 = strconv.FormatFloat(v, 'f', p, 64)
-		case int:
-			v2 = strconv.Itoa(v)
-		case uint32:
-			v2 = strconv.Itoa(int(v))
-		case *[]byte:
-			v2 = string(*v)
-		case *string:
-			v2 = *v
-		case nil:
-		case bool:
-			if v {
-				v2 = "yes"
-			} else {
-				v2 = ""
-			}
-		case string:
-			v2 = v
-		default:
-			v2 = fmt.Sprintf("%v", v)
+		v = nullable(v)
+		if len(t.parsers) > 0 {
+			v = t.parse(i, v)
+		}
+		row.values[i] = v
+		row.cells[i] = t.interned(t.cellString(i, v))
+		if k := kindOf(v); k > t.kinds[i] {
+			t.kinds[i] = k
 		}
-		if len([]rune(v2)) > t.widths[i] {
-			t.widths[i] = len([]rune(v2))
+		if _, ok := v.(string); ok {
+			row.values[i] = row.cells[i]
 		}
-		row[i] = v2
 	}
 	t.rows = append(t.rows, row)
 }
 
-func appendWhitespace(b []byte, count int) []byte {
-	for i := 0; i < count; i++ {
-		b = append(b, ' ')
+// cellString converts value v of column i to the string printed, as set by Precision and AutoFormat.
+func (t *Table) cellString(i int, v interface{}) string {
+	p := t.precision[i]
+	if p == 0 {
+		p = 2
 	}
-	return b
+	if c := t.convert[i]; c != nil {
+		if s, ok := c(v, p); ok {
+			return s
+		}
+	}
+	return formatValue(v, p)
 }
 
-// Print prints the table headers and rows to a io.Writer.
-// Any error returned is from the underlying io.Writer.
-func (t *Table) Print(out io.Writer) error {
-	var buf []byte
-	for i, w := range t.widths {
-		if t.maxWidths[i] > 0 && w > t.maxWidths[i] {
-			t.widths[i] = t.maxWidths[i]
-		}
+// footerRow is the row index of footer rows passed to format functions.
+const footerRow = -2
+
+// Footer adds a footer row, printed below a separator line after all other rows, like totals.
+// Footer rows are not sorted or hidden, and only formatted by column formats.
+func (t *Table) Footer(values ...interface{}) {
+	t.lockRows()
+	defer t.unlockRows()
+	if len(values) > t.columns {
+		values = values[:t.columns]
 	}
-	for i, h := range t.headers {
-		if t.maxWidths[i] > 0 && len([]rune(h)) > t.maxWidths[i] {
-			h = h[:t.maxWidths[i]-3] + "..."
-		}
-		l := t.widths[i] + t.padding
-		p := l - len([]rune(h))
-		if t.formatHeader != nil {
-			h = t.formatHeader(h)
+	cells := make([]string, len(values))
+	for i, v := range values {
+		cells[i] = t.cellString(i, v)
+	}
+	t.footers = append(t.footers, cells)
+}
+
+// kind records whether the values added to a column are all numbers.
+type kind uint8
+
+const (
+	kindUnknown kind = iota // no values added yet
+	kindNumeric
+	kindOther
+)
+
+// kindOf returns the kind of value v. Nil values don't affect the kind of a column.
+func kindOf(v interface{}) kind {
+	switch v.(type) {
+	case nil:
+		return kindUnknown
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return kindNumeric
+	}
+	return kindOther
+}
+
+// Intern enables or disables interning of cell values. When enabled, identical values of rows
+// added later share the same string, which greatly reduces the memory held by large tables with
+// many repeated values, like status names or hostnames.
+func (t *Table) Intern(enabled bool) {
+	switch {
+	case !enabled:
+		t.intern = nil
+	case t.intern == nil:
+		t.intern = make(map[string]string)
+	}
+}
+
+// interned returns the interned copy of s, if interning is enabled.
+func (t *Table) interned(s string) string {
+	if t.intern == nil {
+		return s
+	}
+	if c, ok := t.intern[s]; ok {
+		return c
+	}
+	t.intern[s] = s
+	return s
+}
+
+// Grow preallocates room for n more rows, avoiding repeated allocations when the number
+// of rows to be added is known in advance.
+func (t *Table) Grow(n int) {
+	if n <= 0 {
+		return
+	}
+	if free := cap(t.rows) - len(t.rows); free < n {
+		rows := make([]row, len(t.rows), len(t.rows)+n)
+		copy(rows, t.rows)
+		t.rows = rows
+	}
+	t.spareCells = make([]string, n*t.columns)
+	t.spareValues = make([]interface{}, n*t.columns)
+}
+
+// Approximate sizes in bytes used by EstimateSize, as on 64-bit platforms.
+const (
+	sizeString    = 16
+	sizeInterface = 16
+	sizeRow       = 64
+)
+
+// EstimateSize returns the approximate number of bytes of memory held by the table rows,
+// including the preallocated room of Grow. When interning is enabled, each distinct value is counted once.
+func (t *Table) EstimateSize() int {
+	n := cap(t.rows) * sizeRow
+	n += cap(t.spareCells)*sizeString + cap(t.spareValues)*sizeInterface
+	for s := range t.intern {
+		n += 2*sizeString + len(s)
+	}
+	// the content of interned strings is already counted
+	content := func(s string) int {
+		if t.intern != nil {
+			return 0
 		}
-		buf = append(buf[:0], []byte(h)...)
-		if i != t.columns-1 {
-			buf = appendWhitespace(buf, p)
+		return len(s)
+	}
+	for j := range t.rows {
+		r := &t.rows[j]
+		n += cap(r.cells) * sizeString
+		for _, c := range r.cells {
+			n += content(c)
 		}
-		if _, err := out.Write(buf); err != nil {
-			return err
+		n += cap(r.values) * sizeInterface
+		for _, v := range r.values {
+			switch v := v.(type) {
+			case string:
+				n += sizeString + content(v)
+			case nil:
+			default:
+				n += 8
+			}
 		}
 	}
-	if _, err := out.Write([]byte("\n")); err != nil {
-		return err
+	return n
+}
+
+// allocCells returns a slice of n cells, taken from the room preallocated by Grow if possible.
+func (t *Table) allocCells(n int) []string {
+	if len(t.spareCells) < n {
+		return make([]string, n)
 	}
-	for j, row := range t.rows {
-		for i, r := range row {
-			if t.maxWidths[i] > 0 && len([]rune(r)) > t.maxWidths[i] {
-				r = r[:t.maxWidths[i]-3] + "..."
-			}
-			l := t.widths[i] + t.padding
-			p := l - len([]rune(r))
-			switch {
-			case t.formatNotZero[i] != nil && r != "0":
-				r = t.formatNotZero[i](r)
-			case t.formatRow[j] != nil:
-				r = t.formatRow[j](r)
-			case t.format[i] != nil:
-				r = t.format[i](r)
-			}
-			buf = append(buf[:0], []byte(r)...)
-			if i != t.columns-1 {
-				buf = appendWhitespace(buf, p)
-			}
-			if _, err := out.Write(buf); err != nil {
-				return err
-			}
-		}
-		if _, err := out.Write([]byte("\n")); err != nil {
-			return err
+	c := t.spareCells[:n:n]
+	t.spareCells = t.spareCells[n:]
+	return c
+}
+
+// allocValues returns an empty slice with capacity for n values,
+// taken from the room preallocated by Grow if possible.
+func (t *Table) allocValues(n int) []interface{} {
+	if len(t.spareValues) < n {
+		return make([]interface{}, 0, n)
+	}
+	v := t.spareValues[:0:n]
+	t.spareValues = t.spareValues[n:]
+	return v
+}
+
+// formatValue converts a row value to a string, printing floats with p digits.
+func formatValue(v interface{}, p int) string {
+	switch v := v.(type) {
+	case int32:
+		return strconv.Itoa(int(v))
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', p, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', p, 64)
+	case int:
+		return strconv.Itoa(v)
+	case uint32:
+		return strconv.Itoa(int(v))
+	case RawCell:
+		return v.s
+	case *[]byte:
+		return string(*v)
+	case *string:
+		return *v
+	case nil:
+		return ""
+	case bool:
+		if v {
+			return "yes"
 		}
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprintf("%v", v)
 	}
-	return nil
 }
 
 func max(a, b int) int {
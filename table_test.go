@@ -1,9 +1,19 @@
 package table_test
 
 import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"runtime"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/jayloop/table"
 )
@@ -18,9 +28,9 @@ func Example() {
 	t.Print(os.Stdout)
 	// Output:
 	// key  value
-	// c    0.00
-	// a    1
-	// b    2.00
+	// c     0.00
+	// a        1
+	// b     2.00
 }
 
 func TestTable(t *testing.T) {
@@ -38,6 +48,392 @@ func TestTable(t *testing.T) {
 	tbl.Print(os.Stdout)
 }
 
+func TestUnsort(t *testing.T) {
+	tbl := table.New("name")
+	for _, n := range []string{"c", "a", "d", "b"} {
+		tbl.Row(n)
+	}
+	tbl.Sort(0)
+	tbl.Unsort()
+	var b strings.Builder
+	tbl.Print(&b)
+	if got, want := b.String(), "name\nc\na\nd\nb\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	tbl := table.New("name")
+	tbl.Row("a")
+	s := tbl.Snapshot()
+	tbl.Row("b")
+	tbl.MaxWidth(5, 0)
+	tbl.Restore(s)
+	var b strings.Builder
+	tbl.Print(&b)
+	if got, want := b.String(), "name\na\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestLineEnding(t *testing.T) {
+	tbl := table.New("a", "b")
+	tbl.LineEnding("\r\n")
+	tbl.FinalNewline(false)
+	tbl.Row(1, 2)
+	var b strings.Builder
+	tbl.Print(&b)
+	if got, want := b.String(), "a  b\r\n1  2"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestAppendTo(t *testing.T) {
+	tbl := table.New("a", "b")
+	tbl.Row(1, 2)
+	if got, want := string(tbl.AppendTo([]byte("> "))), "> a  b\n1  2\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := string(tbl.Bytes()), "a  b\n1  2\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestGrow(t *testing.T) {
+	tbl := table.New("n", "square")
+	tbl.Grow(10)
+	for i := 0; i < 12; i++ {
+		tbl.Row(i, i*i)
+	}
+	if got, want := tbl.Len(), 12; got != want {
+		t.Errorf("got %d rows, want %d", got, want)
+	}
+	if lines := strings.Count(string(tbl.Bytes()), "\n"); lines != 13 {
+		t.Errorf("got %d lines, want 13", lines)
+	}
+}
+
+func TestEstimateSize(t *testing.T) {
+	tbl := table.New("key", "value")
+	empty := tbl.EstimateSize()
+	for i := 0; i < 100; i++ {
+		tbl.Row("some key", strings.Repeat("x", 100))
+	}
+	if n := tbl.EstimateSize(); n < empty+100*200 {
+		t.Errorf("estimated size %d is too small", n)
+	}
+}
+
+func TestIntern(t *testing.T) {
+	fill := func(tbl *table.Table) {
+		for i := 0; i < 1000; i++ {
+			tbl.Row(i%3, strings.Repeat("running", 10))
+		}
+	}
+	plain, interned := table.New("id", "status"), table.New("id", "status")
+	interned.Intern(true)
+	fill(plain)
+	fill(interned)
+	if a, b := plain.EstimateSize(), interned.EstimateSize(); b >= a {
+		t.Errorf("interned size %d not smaller than %d", b, a)
+	}
+	if a, b := string(plain.Bytes()), string(interned.Bytes()); a != b {
+		t.Errorf("interned table prints differently")
+	}
+}
+
+func TestCacheFormat(t *testing.T) {
+	calls := 0
+	tbl := table.New("name")
+	tbl.CacheFormat(true)
+	tbl.FormatCols(func(s string) string {
+		calls++
+		return "<" + s + ">"
+	}, 0)
+	tbl.Row("a")
+	tbl.Row("b")
+	first := string(tbl.Bytes())
+	if second := string(tbl.Bytes()); second != first {
+		t.Errorf("got %q, want %q", second, first)
+	}
+	if calls != 2 {
+		t.Errorf("format function called %d times, want 2", calls)
+	}
+	tbl.Row("c")
+	tbl.Bytes()
+	if calls != 3 {
+		t.Errorf("format function called %d times, want 3", calls)
+	}
+}
+
+func TestCacheFormatSorted(t *testing.T) {
+	eu, us := table.New("pod", "restarts"), table.New("pod", "restarts")
+	eu.Row("web", 2)
+	us.Row("web", 1)
+	tbl := table.New("pod", "restarts")
+	tbl.CacheFormat(true)
+	tbl.Append(eu, "eu")
+	tbl.Append(us, "us")
+	tbl.FormatSource(func(s string) string { return "<" + s + ">" }, "eu")
+	tbl.Bytes()
+	tbl.Sort(1)
+	want := "pod    restarts\n" +
+		"web           1\n" +
+		"<web>       <2>\n"
+	if got := string(tbl.Bytes()); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDetectTone(t *testing.T) {
+	defer os.Setenv("COLORFGBG", os.Getenv("COLORFGBG"))
+	for env, want := range map[string]table.Tone{
+		"15;0":        table.ToneDark,
+		"0;15":        table.ToneLight,
+		"0;default;7": table.ToneLight,
+	} {
+		os.Setenv("COLORFGBG", env)
+		if got := table.DetectTone(); got != want {
+			t.Errorf("COLORFGBG=%s: got tone %d, want %d", env, got, want)
+		}
+	}
+}
+
+// restoreEnv returns a function restoring the environment variables keys to their current
+// values, unsetting those not set now.
+func restoreEnv(keys ...string) func() {
+	values := make(map[string]*string, len(keys))
+	for _, k := range keys {
+		if v, ok := os.LookupEnv(k); ok {
+			values[k] = &v
+		} else {
+			values[k] = nil
+		}
+	}
+	return func() {
+		for k, v := range values {
+			if v != nil {
+				os.Setenv(k, *v)
+			} else {
+				os.Unsetenv(k)
+			}
+		}
+	}
+}
+
+func TestColorLevel(t *testing.T) {
+	tbl := table.New("name")
+	tbl.FormatHeader(table.Format(table.Red))
+	tbl.Row("a")
+	tbl.ColorLevel(table.ColorNone)
+	if got, want := string(tbl.Bytes()), "name\na\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	defer restoreEnv("FORCE_COLOR")()
+	os.Setenv("FORCE_COLOR", "0")
+	tbl.ColorLevel(table.ColorDefault)
+	if got, want := string(tbl.Bytes()), "name\na\n"; got != want {
+		t.Errorf("FORCE_COLOR=0: got %q, want %q", got, want)
+	}
+	os.Setenv("FORCE_COLOR", "1")
+	if got, want := string(tbl.Bytes()), "\x1b[31mname\x1b[0m\na\n"; got != want {
+		t.Errorf("FORCE_COLOR=1: got %q, want %q", got, want)
+	}
+}
+
+func TestOnRowPrinted(t *testing.T) {
+	tbl := table.New("name", "size")
+	tbl.Row("b", 2)
+	tbl.Row("a", 1)
+	tbl.Sort(0)
+	var lines []string
+	tbl.OnRowPrinted(func(i int, line string) {
+		lines = append(lines, fmt.Sprintf("%d:%s", i, line))
+	})
+	tbl.Bytes()
+	if got, want := strings.Join(lines, "|"), "0:a        1|1:b        2"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestOnRowPrintedMultiline(t *testing.T) {
+	tbl := table.New("name", "n")
+	tbl.Row("x\ny\nz", 1)
+	tbl.Row("w", 2)
+	var b strings.Builder
+	var lines []string
+	tbl.OnRowPrinted(func(i int, line string) {
+		// the line is written by the time the function is called
+		if !strings.HasSuffix(b.String(), line) {
+			t.Errorf("line %q not written before the call", line)
+		}
+		lines = append(lines, fmt.Sprintf("%d:%s", i, line))
+	})
+	tbl.Print(&b)
+	if got, want := strings.Join(lines, "|"), "0:x     1|0:y      |0:z      |1:w     2"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestProgress(t *testing.T) {
+	tbl := table.New("n")
+	for i := 0; i < 1000; i++ {
+		tbl.Row(i)
+	}
+	calls, last := 0, 0
+	tbl.Progress(500, func(done, total int) {
+		calls++
+		last = done
+	})
+	tbl.Bytes()
+	if calls != 101 || last != 1000 {
+		t.Errorf("got %d calls ending at %d, want 101 ending at 1000", calls, last)
+	}
+	calls = 0
+	tbl.Progress(5000, func(done, total int) { calls++ })
+	tbl.Bytes()
+	if calls != 0 {
+		t.Errorf("got %d calls below the threshold", calls)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	tbl := table.New("name")
+	tbl.Row("a")
+	h := table.Handler(tbl)
+	for accept, code := range map[string]int{
+		"":                        http.StatusOK,
+		"text/html;q=0.9, */*":    http.StatusOK,
+		"text/*":                  http.StatusOK,
+		"image/png":               http.StatusNotAcceptable,
+		"text/plain;q=0, */*;q=0": http.StatusNotAcceptable,
+	} {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != code {
+			t.Errorf("Accept %q: got status %d, want %d", accept, w.Code, code)
+		}
+		if code == http.StatusOK && w.Body.String() != "name\na\n" {
+			t.Errorf("Accept %q: got body %q", accept, w.Body.String())
+		}
+	}
+}
+
+func TestHandlerWith(t *testing.T) {
+	tbl := table.New("name", "size")
+	tbl.Row("a", 1)
+	h := table.HandlerWith(tbl, table.RenderOptions{CSV: table.CSVOptions{Delimiter: ';', NoHeader: true}})
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept", "text/csv")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if got, want := w.Body.String(), "a;1\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	// options are passed at render time and don't change the table
+	if got, want := string(tbl.Bytes()), "name  size\na        1\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTrailingSpace(t *testing.T) {
+	tbl := table.New("name", "note")
+	tbl.Row("a", "")
+	tbl.Row("bb", "x")
+	tbl.TrailingSpace(table.TrailingTrim)
+	if got, want := string(tbl.Bytes()), "name  note\na\nbb    x\n"; got != want {
+		t.Errorf("trim: got %q, want %q", got, want)
+	}
+	tbl.TrailingSpace(table.TrailingPad)
+	if got, want := string(tbl.Bytes()), "name  note\na         \nbb    x   \n"; got != want {
+		t.Errorf("pad: got %q, want %q", got, want)
+	}
+}
+
+func TestStrict(t *testing.T) {
+	tbl := table.New("a", "b")
+	tbl.MaxWidth(10, 5)
+	table.Strict(true)
+	defer table.Strict(false)
+	defer func() {
+		if recover() == nil {
+			t.Error("no panic for column index out of range in strict mode")
+		}
+	}()
+	tbl.MaxWidth(10, 2)
+}
+
+func TestNonStrictColumns(t *testing.T) {
+	for name, f := range map[string]func(*table.Table){
+		"Sort":           func(t *table.Table) { t.Sort(5, -1) },
+		"SortStable":     func(t *table.Table) { t.SortStable(-1) },
+		"GroupBy":        func(t *table.Table) { t.GroupBy(-1) },
+		"HideZeroRows":   func(t *table.Table) { t.HideZeroRows(-1) },
+		"PercentOfTotal": func(t *table.Table) { t.PercentOfTotal(-1, "%") },
+		"Delta":          func(t *table.Table) { t.Delta(-1, "delta", false) },
+		"CumulativeSum":  func(t *table.Table) { t.CumulativeSum(-1, "sum") },
+		"Rank":           func(t *table.Table) { t.Rank(-1, "rank", table.TiesDense) },
+		"Sparkline":      func(t *table.Table) { t.Sparkline(1, -1, "trend", 5) },
+	} {
+		tbl := table.New("name", "size")
+		tbl.Row("a", 2)
+		tbl.Row("b", 1)
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("%s: panic for column index out of range in non-strict mode: %v", name, r)
+				}
+			}()
+			f(tbl)
+			tbl.Bytes()
+		}()
+	}
+}
+
+func TestMapValuesDeterministic(t *testing.T) {
+	m := map[string]int{"d": 4, "b": 2, "a": 1, "c": 3, "e": 5}
+	for i := 0; i < 20; i++ {
+		tbl := table.New("value")
+		tbl.Row(m)
+		if got, want := string(tbl.Bytes()), "value\nmap[a:1 b:2 c:3 d:4 e:5]\n"; got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+}
+
+func TestMeta(t *testing.T) {
+	tbl := table.New("name")
+	tbl.Row("b")
+	tbl.SetRowMeta(-1, table.Meta{"id": "2"})
+	tbl.Row("a")
+	tbl.SetCellMeta(-1, 0, table.Meta{"href": "/a"})
+	tbl.Sort(0)
+	if got := tbl.CellMeta(0, 0)["href"]; got != "/a" {
+		t.Errorf("got cell meta %q, want /a", got)
+	}
+	if got := tbl.RowMeta(1)["id"]; got != "2" {
+		t.Errorf("got row meta %q, want 2", got)
+	}
+	if got, want := string(tbl.Bytes()), "name\na\nb\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPadToRows(t *testing.T) {
+	tbl := table.New("name", "n")
+	tbl.CumulativeSum(1, "sum")
+	tbl.Row("b", 1)
+	tbl.Row("a", 2)
+	tbl.PadToRows(4)
+	tbl.Sort(0)
+	tbl.TrailingSpace(table.TrailingTrim)
+	if got, want := string(tbl.Bytes()), "name  n  sum\na     2    2\nb     1    3\n\n\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
 func TestTableFromStruct(t *testing.T) {
 	var m runtime.MemStats
 	runtime.ReadMemStats(&m)
@@ -50,3 +446,1682 @@ func TestTableFromStruct(t *testing.T) {
 	tbl.Sort(0)
 	tbl.Print(os.Stdout)
 }
+
+func ExampleTable_Merge() {
+	t := table.New("region", "zone", "host")
+	t.Merge(0, 1)
+	t.Row("eu", "1", "a")
+	t.Row("eu", "1", "b")
+	t.Row("us", "1", "c")
+	t.Row("us", "2", "d")
+	t.Print(os.Stdout)
+	// Output:
+	// region  zone  host
+	// eu      1     a
+	//               b
+	// us      1     c
+	//         2     d
+}
+
+func ExampleTable_ShowIndex() {
+	t := table.New("name", "size")
+	t.ShowIndex("#")
+	t.Row("b", 20)
+	t.Row("c", 30)
+	t.Row("a", 10)
+	t.Sort(0)
+	t.Print(os.Stdout)
+	// Output:
+	// #  name  size
+	// 2  a       10
+	// 0  b       20
+	// 1  c       30
+}
+
+func ExampleTable_AutoPrecision() {
+	t := table.New("name", "value")
+	t.AutoPrecision(1)
+	t.Row("a", 0.001)
+	t.Row("b", 123.45)
+	t.Row("c", 7.0)
+	t.Print(os.Stdout)
+	// Output:
+	// name    value
+	// a       0.001
+	// b     123.450
+	// c       7.000
+}
+
+func ExampleTable_PercentOfTotal() {
+	t := table.New("service", "requests")
+	t.Precision(1, t.PercentOfTotal(1, "share"))
+	t.Row("api", 600)
+	t.Row("web", 300)
+	t.Row("cron", 100)
+	t.Print(os.Stdout)
+	// Output:
+	// service  requests  share
+	// api           600  60.0%
+	// web           300  30.0%
+	// cron          100  10.0%
+}
+
+func TestDelta(t *testing.T) {
+	tbl := table.New("day", "users")
+	tbl.Delta(1, "change", false)
+	tbl.Precision(1, tbl.Delta(1, "%", true))
+	tbl.Row("mon", 100)
+	tbl.Row("tue", 120)
+	tbl.Row("wed", 90)
+	var b strings.Builder
+	tbl.Print(&b)
+	want := "day  users  change       %\n" +
+		"mon    100                \n" +
+		"tue    120     +20  +20.0%\n" +
+		"wed     90     -30  -25.0%\n"
+	if got := b.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDeltaIntegerTypes(t *testing.T) {
+	tbl := table.New("day", "users")
+	tbl.Delta(1, "change", false)
+	tbl.CumulativeSum(1, "total")
+	tbl.Row("mon", int8(10))
+	tbl.Row("tue", uint16(30))
+	tbl.Row("wed", uint(25))
+	tbl.Row("thu", uint8(5))
+	want := "day  users  change  total\n" +
+		"mon     10             10\n" +
+		"tue     30     +20     40\n" +
+		"wed     25      -5     65\n" +
+		"thu      5     -20     70\n"
+	if got := string(tbl.Bytes()); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDeltaHiddenRows(t *testing.T) {
+	tbl := table.New("day", "users")
+	tbl.Delta(1, "change", false)
+	tbl.CumulativeSum(1, "total")
+	tbl.HideZeroRows(0)
+	tbl.Row("mon", 100)
+	tbl.Row("tue", 0)
+	tbl.Row("wed", 90)
+	want := "day  users  change  total\n" +
+		"mon    100            100\n" +
+		"wed     90     -10    190\n"
+	if got := string(tbl.Bytes()); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func ExampleTable_CumulativeSum() {
+	t := table.New("month", "sales")
+	t.CumulativeSum(1, "total")
+	t.Row("feb", 20)
+	t.Row("jan", 10)
+	t.Row("mar", 5)
+	t.Sort(1)
+	t.Print(os.Stdout)
+	// Output:
+	// month  sales  total
+	// jan       10     10
+	// feb       20     30
+	// mar        5     35
+}
+
+func ExampleTable_Rank() {
+	t := table.New("player", "score")
+	t.Rank(1, "rank", table.TiesCompetition)
+	t.Rank(1, "dense", table.TiesDense)
+	t.Row("ann", 30)
+	t.Row("bob", 50)
+	t.Row("cid", 30)
+	t.Row("dan", 10)
+	t.Print(os.Stdout)
+	// Output:
+	// player  score  rank  dense
+	// ann        30     2      2
+	// bob        50     1      1
+	// cid        30     2      2
+	// dan        10     4      3
+}
+
+func ExampleTable_HideZeroRows() {
+	t := table.New("name", "errors", "warnings")
+	t.HideZeroRows(0)
+	t.Row("a", 0, 0)
+	t.Row("b", 2, 0)
+	t.Row("c", 0, 0.0)
+	t.Row("d", 0, 1)
+	t.Print(os.Stdout)
+	// Output:
+	// name  errors  warnings
+	// b          2         0
+	// d          0         1
+}
+
+func ExampleTable_CollapseEmpty() {
+	t := table.New("name", "owner", "labels", "status")
+	t.CollapseEmpty("-")
+	t.Row("a", "", "-", "ok")
+	t.Row("b", "bob", "", "ok")
+	t.Print(os.Stdout)
+	// Output:
+	// name  owner  status
+	// a            ok
+	// b     bob    ok
+}
+
+func ExampleTable_HideConstant() {
+	t := table.New("host", "region", "status")
+	t.HideConstant(true)
+	t.Row("a", "eu-west-1", "up")
+	t.Row("b", "eu-west-1", "down")
+	t.Print(os.Stdout)
+	// Output:
+	// host  status
+	// a     up
+	// b     down
+	// region=eu-west-1 for all rows
+}
+
+func ExampleTable_TotalMaxWidth() {
+	t := table.New("id", "path", "description")
+	t.TotalMaxWidth(30)
+	t.Row(1, "/usr/local/lib/go", "the go installation directory")
+	t.Print(os.Stdout)
+	// Output:
+	// id  path          description
+	//  1  /usr/loca...  the go in...
+}
+
+func ExampleTable_PrintRows() {
+	t := table.New("name", "size")
+	t.Row("alpha", 1)
+	t.PrintHeader(os.Stdout)
+	t.PrintRows(os.Stdout)
+	// Output:
+	// name   size
+	// alpha     1
+}
+
+func ExampleTable_Separator() {
+	t := table.New("name", "size")
+	t.Row("b", 2)
+	t.Row("a", 1)
+	t.Separator()
+	t.Row("c", 3)
+	t.Sort(0)
+	t.Print(os.Stdout)
+	// Output:
+	// name  size
+	// a        1
+	// ----------
+	// b        2
+	// c        3
+}
+
+func ExampleTable_GroupBy() {
+	t := table.New("region", "host")
+	t.GroupBy(0)
+	t.Row("us", "c")
+	t.Row("eu", "a")
+	t.Row("eu", "b")
+	t.Sort(0, 1)
+	t.Print(os.Stdout)
+	// Output:
+	// region  host
+	// eu      a
+	// eu      b
+	//
+	// us      c
+}
+
+func ExampleFormatFunc() {
+	t := table.New("name", "status")
+	t.FormatCols(func(s string) string {
+		if s == "down" {
+			return "!! " + s
+		}
+		return s
+	}, 1)
+	t.FormatHeader(table.Format(table.Bold))
+	t.ColorLevel(table.ColorBasic)
+	t.Row("web", "down")
+	t.Row("db", "up")
+	fmt.Print(strings.ReplaceAll(string(t.Bytes()), "\x1b", "^"))
+	// Output:
+	// ^[1mname^[0m  ^[1mstatus^[0m
+	// web   !! down
+	// db    up
+}
+
+func ExampleStyle() {
+	t := table.New("name", "status")
+	t.StyleCols(table.Style{Fg: table.Red, Attrs: []table.CodeANSI{table.Bold}}, 1)
+	t.ColorLevel(table.ColorBasic)
+	t.Row("web", "down")
+	fmt.Print(strings.ReplaceAll(string(t.Bytes()), "\x1b", "^"))
+	// Output:
+	// name  status
+	// web   ^[31;1mdown^[0m
+}
+
+func ExampleFprint() {
+	table.Fprint(os.Stdout, []string{"name", "size"}, [][]interface{}{
+		{"b", 2},
+		{"a", 1.5},
+	}, table.SortBy(0), func(t *table.Table) { t.Precision(1, 1) })
+	// Output:
+	// name  size
+	// a      1.5
+	// b        2
+}
+
+func ExamplePrintStructs() {
+	type user struct {
+		Name   string
+		Age    int    `table:"age"`
+		Secret string `table:"-"`
+		note   string
+	}
+	table.PrintStructs(os.Stdout, []*user{
+		{Name: "bob", Age: 42, Secret: "x"},
+		{Name: "ann", Age: 37},
+	}, table.SortBy(0))
+	// Output:
+	// Name  age
+	// ann    37
+	// bob    42
+}
+
+func ExampleTable_Layout() {
+	t := table.New("name", "size")
+	t.Row("alpha", 1)
+	t.Separator()
+	t.Row("b", 20000)
+	fmt.Printf("%+v\n", t.Layout())
+	// Output:
+	// {Columns:[name size] Widths:[5 5] Width:12 Rows:2 Lines:4}
+}
+
+func ExampleTable_Truncator() {
+	t := table.New("commit", "message")
+	t.MaxWidth(9, 0)
+	t.Truncator(func(s string, w int) string {
+		r := []rune(s)
+		return string(r[:w/2]) + "~" + string(r[len(r)-(w-1)/2:])
+	}, 0)
+	t.Row("c493598e0a7f", "baseline")
+	t.Print(os.Stdout)
+	// Output:
+	// commit     message
+	// c493~0a7f  baseline
+}
+
+func ExampleTable_Pseudonymize() {
+	t := table.New("customer", "orders")
+	t.Pseudonymize(0)
+	t.Row("acme", 3)
+	t.Row("globex", 1)
+	t.Row("acme", 2)
+	t.Print(os.Stdout)
+	// Output:
+	// customer    orders
+	// customer-1       3
+	// customer-2       1
+	// customer-1       2
+}
+
+func ExampleTable_AutoFormat() {
+	t := table.New("name", "size_bytes", "cpu_pct", "created_at")
+	t.AutoFormat()
+	t.Row("a", 1536, 12.5, time.Now().Add(-5*time.Minute))
+	t.Row("b", int64(3<<30), 0.25, time.Now().Add(-50*time.Hour))
+	t.Print(os.Stdout)
+	// Output:
+	// name  size_bytes  cpu_pct  created_at
+	// a        1.5 KiB   12.50%  5m ago
+	// b        3.0 GiB    0.25%  2d ago
+}
+
+func TestAutoFormatRelativeToPrint(t *testing.T) {
+	tbl := table.New("name", "created_at")
+	tbl.AutoFormat()
+	tbl.Row("a", time.Now().Add(-59*time.Second-900*time.Millisecond))
+	if got, want := string(tbl.Bytes()), "name  created_at\na     59s ago\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if got, want := string(tbl.Bytes()), "name  created_at\na     1m ago\n"; got != want {
+		t.Errorf("printed later: got %q, want %q", got, want)
+	}
+}
+
+func ExampleTable_UseWidths() {
+	page1 := table.New("name", "size")
+	page1.Row("a-long-name", 1)
+	page1.Print(os.Stdout)
+	page2 := table.New("name", "size")
+	page2.UseWidths(page1.SaveWidths())
+	page2.Row("b", 2)
+	page2.PrintRows(os.Stdout)
+	// Output:
+	// name         size
+	// a-long-name     1
+	// b               2
+}
+
+func ExampleTable_SortKey() {
+	t := table.New("name", "size")
+	t.SortKey(1, func(v string) interface{} {
+		n, _ := strconv.Atoi(strings.TrimSuffix(v, " MB"))
+		return n
+	})
+	t.Row("a", "100 MB")
+	t.Row("b", "20 MB")
+	t.Row("c", "3 MB")
+	t.Sort(1)
+	t.Print(os.Stdout)
+	// Output:
+	// name  size
+	// c     3 MB
+	// b     20 MB
+	// a     100 MB
+}
+
+func ExampleTable_Align() {
+	t := table.New("name", "state", "size")
+	t.Align(table.Center, 1)
+	t.Align(table.Right, 2)
+	t.Row("alpha", "up", 1)
+	t.Row("b", "down", 1000)
+	t.Print(os.Stdout)
+	// Output:
+	// name   state  size
+	// alpha   up       1
+	// b      down   1000
+}
+
+func ExampleTable_Compact() {
+	t := table.New("host", "region", "note")
+	t.CollapseEmpty("-")
+	t.GroupBy(1)
+	t.Compact(true)
+	t.Row("web-1", "eu", "-")
+	t.Row("web-2", "eu", "")
+	t.Row("db-1", "us", "-")
+	t.Print(os.Stdout)
+	// Output:
+	// host  region
+	// web-1 eu
+	// web-2 eu
+	// db-1  us
+}
+
+func ExampleTable_Align_numeric() {
+	t := table.New("name", "size", "code")
+	// numeric columns are right aligned unless set otherwise
+	t.Align(table.Left, 2)
+	t.Row("a", 1, 7)
+	t.Row("b", 1000, 42)
+	t.Print(os.Stdout)
+	// Output:
+	// name  size  code
+	// a        1  7
+	// b     1000  42
+}
+
+func TestAlignDecimal(t *testing.T) {
+	tbl := table.New("name", "value")
+	tbl.Precision(-1, 1)
+	tbl.AlignDecimal(1)
+	tbl.Row("a", 0.001)
+	tbl.Row("b", 123.45)
+	tbl.Row("c", 7)
+	tbl.Row("d", "n/a")
+	want := "name  value\n" +
+		"a       0.001\n" +
+		"b     123.45 \n" +
+		"c       7    \n" +
+		"d     n/a\n"
+	if got := string(tbl.Bytes()); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestAlignDecimalFormatSign(t *testing.T) {
+	tbl := table.New("name", "change", "errors")
+	tbl.AlignDecimal(1, 2)
+	tbl.FormatSign(func(s string) string { return "+" + s }, func(s string) string { return "-" + s }, 1)
+	tbl.FormatNotZero(func(s string) string { return "*" + s }, 2)
+	tbl.Row("a", 1.5, 0)
+	tbl.Row("b", -12, 0.25)
+	want := "name   change  errors\n" +
+		"a     +  1.50    0   \n" +
+		"b     --12      *0.25\n"
+	if got := string(tbl.Bytes()); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func ExampleTable_StatusLine() {
+	t := table.New("name", "region", "size")
+	t.StatusLine(table.Bottom, "12:00", "hosts", "2 rows")
+	t.Row("web-1", "eu-west", 10)
+	t.Row("web-2", "us-east", 20)
+	t.Print(os.Stdout)
+	// Output:
+	// name   region   size
+	// web-1  eu-west    10
+	// web-2  us-east    20
+	// 12:00  hosts  2 rows
+}
+
+func ExampleTable_Borders() {
+	t := table.New("region", "host", "size")
+	t.Borders(table.BorderASCII)
+	t.Merge(0)
+	t.Row("eu", "web-1", 10)
+	t.Row("eu", "web-2", 200)
+	t.Separator()
+	t.Row("us", "db-1", 3)
+	t.Print(os.Stdout)
+	// Output:
+	// +--------+-------+------+
+	// | region | host  | size |
+	// +--------+-------+------+
+	// | eu     | web-1 |   10 |
+	// |        | web-2 |  200 |
+	// +--------+-------+------+
+	// | us     | db-1  |    3 |
+	// +--------+-------+------+
+}
+
+func TestMergeSeparators(t *testing.T) {
+	tbl := table.New("region", "host", "size")
+	tbl.Borders(table.BorderASCII)
+	tbl.SeparateEvery(1)
+	tbl.Merge(0)
+	tbl.Row("eu", "web-1", 10)
+	tbl.Row("eu", "web-2", 200)
+	tbl.Row("us", "db-1", 3)
+	want := `+--------+-------+------+
+| region | host  | size |
++--------+-------+------+
+| eu     | web-1 |   10 |
+|        +-------+------+
+|        | web-2 |  200 |
++--------+-------+------+
+| us     | db-1  |    3 |
++--------+-------+------+
+`
+	if got := string(tbl.Bytes()); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	tbl.Borders(table.BorderNone)
+	want = `region  host   size
+eu      web-1    10
+        -----------
+        web-2   200
+-------------------
+us      db-1      3
+`
+	if got := string(tbl.Bytes()); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMergeHTMLMarkdown(t *testing.T) {
+	tbl := table.New("region", "host")
+	tbl.Merge(0)
+	tbl.Row("eu", "web-1")
+	tbl.Row("eu", "web-2")
+	tbl.Row("us", "db-1")
+	var b strings.Builder
+	tbl.PrintHTML(&b, table.HTMLOptions{})
+	for _, want := range []string{
+		`<tr><td rowspan="2">eu</td><td>web-1</td></tr>`,
+		`<tr><td>web-2</td></tr>`,
+		`<tr><td>us</td><td>db-1</td></tr>`,
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("got %q, want it to contain %q", b.String(), want)
+		}
+	}
+	b.Reset()
+	tbl.PrintMarkdown(&b, table.MarkdownOptions{})
+	want := `| region | host  |
+| ------ | ----- |
+| eu     | web-1 |
+| eu     | web-2 |
+| us     | db-1  |
+`
+	if b.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func ExampleTable_FormatBorder() {
+	t := table.New("host", "size")
+	t.Borders(table.BorderASCII)
+	// colors would be used in a terminal, like table.Format(table.HiBlack)
+	t.FormatBorder(strings.NewReplacer("-", "=", "|", ":").Replace)
+	t.Row("web-1", 10)
+	t.Row("db-1", 3)
+	t.Print(os.Stdout)
+	// Output:
+	// +=======+======+
+	// : host  : size :
+	// +=======+======+
+	// : web-1 :   10 :
+	// : db-1  :    3 :
+	// +=======+======+
+}
+
+func ExampleTable_ZeroValue() {
+	t := table.New("host", "errors", "warnings")
+	t.ZeroValue(table.ZeroDash, 1)
+	t.ZeroValue(table.ZeroHide, 2)
+	t.Row("web-1", 0, 3)
+	t.Row("web-2", 12, 0)
+	t.Print(os.Stdout)
+	// Output:
+	// host   errors  warnings
+	// web-1       -         3
+	// web-2      12
+}
+
+func TestTrafficLight(t *testing.T) {
+	tbl := table.New("disk", "used", "free")
+	tbl.ColorLevel(table.ColorBasic)
+	tbl.TrafficLight(1, 80, 90)
+	tbl.TrafficLight(2, 20, 10)
+	tbl.Row("a", "50%", 50)
+	tbl.Row("b", "85%", 15)
+	tbl.Row("c", "95%", 5)
+	green, yellow, red := table.Format(table.Green), table.Format(table.Yellow), table.Format(table.Red)
+	want := "disk  used  free\n" +
+		"a     " + green("50%") + "     " + green("50") + "\n" +
+		"b     " + yellow("85%") + "     " + yellow("15") + "\n" +
+		"c     " + red("95%") + "      " + red("5") + "\n"
+	if got := string(tbl.Bytes()); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPercentiles(t *testing.T) {
+	tbl := table.New("host", "load")
+	tbl.ColorLevel(table.ColorBasic)
+	red, faint := table.Format(table.Red), table.Format(table.Faint)
+	tbl.Percentiles(1, red, faint)
+	for _, n := range []int{4, 9, 1, 6, 10, 2, 7, 3, 8, 5} {
+		tbl.Row("h"+strconv.Itoa(n), n)
+	}
+	got := strings.Split(string(tbl.Bytes()), "\n")
+	for _, c := range []struct {
+		line int
+		want string
+	}{
+		{1, "h4       4"},
+		{3, "h1       " + faint("1")},
+		{5, "h10     " + red("10")},
+		{8, "h3       " + faint("3")},
+	} {
+		if got[c.line] != c.want {
+			t.Errorf("line %d: got %q, want %q", c.line, got[c.line], c.want)
+		}
+	}
+}
+
+func TestTrackRows(t *testing.T) {
+	tbl := table.New("host", "cpu")
+	tbl.ColorLevel(table.ColorBasic)
+	green, yellow, red := table.Format(table.Green), table.Format(table.Yellow), table.Format(table.Red)
+	tbl.TrackRows(0, green, yellow, red)
+	s := tbl.Snapshot()
+	for _, c := range []struct {
+		rows [][]interface{}
+		want string
+	}{
+		{[][]interface{}{{"a", 1}, {"b", 2}}, "host  cpu\na       1\nb       2\n"},
+		{[][]interface{}{{"a", 1}, {"b", 3}, {"c", 4}}, "host  cpu\na       1\nb       " + yellow("3") + "\n" + green("c") + "       " + green("4") + "\n"},
+		{[][]interface{}{{"b", 3}}, "host  cpu\nb       3\n" + red("a") + "       " + red("1") + "\n" + red("c") + "       " + red("4") + "\n"},
+	} {
+		tbl.Restore(s)
+		for _, r := range c.rows {
+			tbl.Row(r...)
+		}
+		if got := string(tbl.Bytes()); got != c.want {
+			t.Errorf("got %q, want %q", got, c.want)
+		}
+	}
+	b, err := json.Marshal(tbl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `[{"host":"b","cpu":3}]`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func ExampleTable_Wrap() {
+	t := table.New("id", "description", "owner")
+	t.MaxWidth(16, 1)
+	t.Wrap(1)
+	t.TrailingSpace(table.TrailingTrim)
+	t.Row(1, "a description that does not fit the column", "alice")
+	t.Row(2, "short", "bob")
+	t.Print(os.Stdout)
+	fmt.Println(t.Layout().Lines)
+	// Output:
+	// id  description       owner
+	//  1  a description     alice
+	//     that does not
+	//     fit the column
+	//  2  short             bob
+	// 5
+}
+
+func TestWrapStyled(t *testing.T) {
+	tbl := table.New("word")
+	tbl.ColorLevel(table.ColorBasic)
+	tbl.MaxWidth(4, 0)
+	tbl.Wrap(0)
+	tbl.Row("\x1b[31mabcdefghijkl\x1b[0m")
+	want := "word\n\x1b[31mabcd\x1b[0m\n\x1b[31mefgh\x1b[0m\n\x1b[31mijkl\x1b[0m\n"
+	if got := string(tbl.Bytes()); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func ExampleTable_Row_multiline() {
+	t := table.New("name", "address", "city")
+	t.Borders(table.BorderASCII)
+	t.Row("alice", "1 Main St\nApt 4", "Springfield")
+	t.Row("bob", "22 Elm Rd", "Shelbyville")
+	t.Print(os.Stdout)
+	// Output:
+	// +-------+-----------+-------------+
+	// | name  | address   | city        |
+	// +-------+-----------+-------------+
+	// | alice | 1 Main St | Springfield |
+	// |       | Apt 4     |             |
+	// | bob   | 22 Elm Rd | Shelbyville |
+	// +-------+-----------+-------------+
+}
+
+func ExampleTable_OnDiagnostics() {
+	t := table.New("name", "description", "notes")
+	t.MaxWidth(8, 1, 2)
+	t.Wrap(2)
+	t.OnDiagnostics(func(d table.Diagnostics) {
+		fmt.Println(d.Truncated, d.Wrapped, d.Overflow, d.Layout.Width)
+	})
+	t.Row("a", "short", "fine")
+	t.Row("b", "much too long", "wrapped notes here")
+	t.Print(ioutil.Discard)
+	// Output:
+	// map[1:1] map[2:1] 2 24
+}
+
+func ExampleTable_UseLocale() {
+	t := table.New("region", "revenue", "umsatz", "date")
+	t.UseLocale(table.LocaleUS, 1)
+	t.UseLocale(table.LocaleDE, 2, 3)
+	day := time.Date(2020, 3, 14, 0, 0, 0, 0, time.UTC)
+	t.Row("eu", 1234567.891, -9876.5, day)
+	t.Row("us", 42.0, 1000, day)
+	t.Print(os.Stdout)
+	// Output:
+	// region       revenue     umsatz  date
+	// eu      1,234,567.89  -9.876,50  14.03.2020
+	// us             42.00      1.000  14.03.2020
+}
+
+func TestUseLocaleNumbers(t *testing.T) {
+	tbl := table.New("region", "umsatz", "date")
+	tbl.UseLocale(table.LocaleDE, 1, 2)
+	tbl.AlignDecimal(1)
+	tbl.FormatSign(func(s string) string { return "+" + s }, func(s string) string { return "-" + s }, 1, 2)
+	tbl.ZeroValue(table.ZeroDash, 1)
+	tbl.Row("eu", 1234.5, time.Date(2020, 3, 14, 0, 0, 0, 0, time.UTC))
+	tbl.Row("us", -20001, nil)
+	tbl.Row("ch", 0.0, nil)
+	tbl.Row("uk", math.Inf(1), nil)
+	want := "region       umsatz  date\n" +
+		"eu      +  1.234,50  14.03.2020\n" +
+		"us      --20.001     \n" +
+		"ch                -  \n" +
+		"uk      +   +Inf     \n"
+	if got := string(tbl.Bytes()); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func ExampleTable_wideCharacters() {
+	t := table.New("name", "city")
+	t.Borders(table.BorderASCII)
+	t.MaxWidth(7, 1)
+	t.Row("山田", "東京都渋谷区")
+	t.Row("kim", "서울")
+	t.Row("bob", "Zürich")
+	t.Print(os.Stdout)
+	// Output:
+	// +------+---------+
+	// | name | city    |
+	// +------+---------+
+	// | 山田 | 東京... |
+	// | kim  | 서울    |
+	// | bob  | Zürich  |
+	// +------+---------+
+}
+
+func TestPreStyledValues(t *testing.T) {
+	red := "\x1b[31m"
+	tbl := table.New("name", "status")
+	tbl.ColorLevel(table.ColorBasic)
+	tbl.MaxWidth(8, 1)
+	tbl.Row("a", red+"ok\x1b[0m")
+	tbl.Row("b", red+"failing badly\x1b[0m")
+	want := "name  status\n" +
+		"a     " + red + "ok\x1b[0m\n" +
+		"b     " + red + "faili\x1b[0m...\n"
+	if got := string(tbl.Bytes()); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPrintSnapshot(t *testing.T) {
+	tbl := table.NewSync("n", "square")
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 1000; i++ {
+			tbl.Row(i, i*i)
+		}
+		close(done)
+	}()
+	for i := 0; i < 10; i++ {
+		var b strings.Builder
+		if err := tbl.PrintSnapshot(&b); err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range strings.Split(strings.TrimSpace(b.String()), "\n")[1:] {
+			var n, sq int
+			if _, err := fmt.Sscan(line, &n, &sq); err != nil || sq != n*n {
+				t.Fatalf("inconsistent line %q", line)
+			}
+		}
+	}
+	<-done
+	var b strings.Builder
+	tbl.PrintSnapshot(&b)
+	if got, want := strings.Count(b.String(), "\n"), 1001; got != want {
+		t.Errorf("got %d lines, want %d", got, want)
+	}
+}
+
+func TestPrintSnapshotTracked(t *testing.T) {
+	tbl := table.NewSync("name")
+	tbl.TrackRows(0, nil, nil, table.Format(table.CrossedOut))
+	tbl.ColorLevel(table.ColorBasic)
+	s := tbl.Snapshot()
+	tbl.Row("a")
+	tbl.Row("b")
+	tbl.PrintSnapshot(ioutil.Discard)
+	tbl.Restore(s)
+	tbl.Row("a")
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 100; i++ {
+			tbl.Row("a")
+		}
+		close(done)
+	}()
+	for i := 0; i < 10; i++ {
+		var b strings.Builder
+		tbl.PrintSnapshot(&b)
+		if !strings.HasSuffix(b.String(), "\x1b[9mb\x1b[0m\n") {
+			t.Fatalf("removed row not printed last: %q", b.String())
+		}
+	}
+	<-done
+}
+
+func ExampleTable_Sparkline() {
+	t := table.New("host", "load")
+	t.Sparkline(1, 0, "history", 5)
+	s := t.Snapshot()
+	for _, load := range [][]int{{1, 7}, {2, 7}, {4, 5}, {8, 3}, {6, 1}, {7, 1}} {
+		t.Restore(s)
+		t.Row("a", load[0])
+		t.Row("b", load[1])
+	}
+	t.Print(os.Stdout)
+	// Output:
+	// host  load  history
+	// a        7  ▁▃█▆▇
+	// b        1  █▆▃▁▁
+}
+
+func TestForceColor(t *testing.T) {
+	for _, k := range []string{"FORCE_COLOR", "CLICOLOR_FORCE", "NO_COLOR"} {
+		if v, ok := os.LookupEnv(k); ok {
+			defer os.Setenv(k, v)
+			os.Unsetenv(k)
+		}
+	}
+	tbl := table.New("status")
+	tbl.FormatCols(table.Format(table.Red), 0)
+	tbl.Row("down")
+	plain, colored := "status\ndown\n", "status\n"+table.Format(table.Red)("down")+"\n"
+	if got := string(tbl.Bytes()); got != plain {
+		t.Errorf("not a terminal: got %q, want %q", got, plain)
+	}
+	table.ForceColor(true)
+	defer table.ForceColor(false)
+	if got := string(tbl.Bytes()); got != colored {
+		t.Errorf("forced: got %q, want %q", got, colored)
+	}
+	os.Setenv("NO_COLOR", "1")
+	defer os.Unsetenv("NO_COLOR")
+	if got := string(tbl.Bytes()); got != colored {
+		t.Errorf("forced with NO_COLOR: got %q, want %q", got, colored)
+	}
+	table.ForceColor(false)
+	tbl.ColorLevel(table.ColorBasic)
+	if got := string(tbl.Bytes()); got != colored {
+		t.Errorf("table level: got %q, want %q", got, colored)
+	}
+}
+
+func TestTermColorLevel(t *testing.T) {
+	keys := []string{"FORCE_COLOR", "CLICOLOR_FORCE", "NO_COLOR", "COLORTERM", "TERM"}
+	defer restoreEnv(keys...)()
+	for _, k := range keys {
+		os.Unsetenv(k)
+	}
+	table.ForceColor(true)
+	defer table.ForceColor(false)
+	tbl := table.New("status")
+	tbl.FormatCols(table.FormatRGB(0xaf, 0, 0), 0)
+	tbl.Row("down")
+	for _, c := range []struct {
+		colorterm, term string
+		want            string
+	}{
+		{"truecolor", "xterm", "\x1b[38;2;175;0;0mdown\x1b[0m"},
+		{"24bit", "", "\x1b[38;2;175;0;0mdown\x1b[0m"},
+		{"", "xterm-256color", "\x1b[38;5;124mdown\x1b[0m"},
+		{"", "screen-256color", "\x1b[38;5;124mdown\x1b[0m"},
+		{"", "xterm", "\x1b[31mdown\x1b[0m"},
+		// forced colors are at least basic, even for the dumb terminal
+		{"", "dumb", "\x1b[31mdown\x1b[0m"},
+	} {
+		os.Setenv("COLORTERM", c.colorterm)
+		os.Setenv("TERM", c.term)
+		if got, want := string(tbl.Bytes()), "status\n"+c.want+"\n"; got != want {
+			t.Errorf("COLORTERM=%q TERM=%q: got %q, want %q", c.colorterm, c.term, got, want)
+		}
+	}
+}
+
+func ExampleTable_SortStable() {
+	t := table.New("name", "size")
+	t.Row("b", 10)
+	t.Row("a", 9)
+	t.Row("c", 10)
+	t.Row("d", 100)
+	t.SortStable(1)
+	t.Print(os.Stdout)
+	// Output:
+	// name  size
+	// a        9
+	// b       10
+	// c       10
+	// d      100
+}
+
+func TestSorted(t *testing.T) {
+	tbl := table.New("name", "n")
+	tbl.Sorted(0)
+	tbl.Row("b", 2)
+	tbl.Row("a", 1)
+	tbl.Sort(0)
+	if got, want := string(tbl.Bytes()), "name  n\nb     2\na     1\n"; got != want {
+		t.Errorf("marked as sorted: got %q, want %q", got, want)
+	}
+	tbl.Sort(1)
+	tbl.Unsort()
+	tbl.Sort(0)
+	if got, want := string(tbl.Bytes()), "name  n\nb     2\na     1\n"; got != want {
+		t.Errorf("unsorted: got %q, want %q", got, want)
+	}
+	tbl.SortStable(1)
+	tbl.Sort(0)
+	if got, want := string(tbl.Bytes()), "name  n\na     1\nb     2\n"; got != want {
+		t.Errorf("sorted by another column: got %q, want %q", got, want)
+	}
+}
+
+func TestFormat256(t *testing.T) {
+	tbl := table.New("a", "b", "c")
+	tbl.FormatCols(table.Format256(196), 0)
+	tbl.FormatCols(table.FormatRGB(255, 0, 0), 1)
+	tbl.FormatCols(table.FormatRGBBg(0, 0, 200), 2)
+	tbl.Row("x", "y", "z")
+	for _, c := range []struct {
+		level table.ColorLevel
+		want  string
+	}{
+		{table.ColorTrue, "\x1b[38;5;196mx\x1b[0m  \x1b[38;2;255;0;0my\x1b[0m  \x1b[48;2;0;0;200mz\x1b[0m"},
+		{table.Color256, "\x1b[38;5;196mx\x1b[0m  \x1b[38;5;196my\x1b[0m  \x1b[48;5;20mz\x1b[0m"},
+		{table.ColorBasic, "\x1b[91mx\x1b[0m  \x1b[91my\x1b[0m  \x1b[104mz\x1b[0m"},
+		{table.ColorNone, "x  y  z"},
+	} {
+		tbl.ColorLevel(c.level)
+		got := strings.Split(string(tbl.Bytes()), "\n")[1]
+		if got != c.want {
+			t.Errorf("level %d: got %q, want %q", c.level, got, c.want)
+		}
+	}
+}
+
+func TestFormat256OutOfRange(t *testing.T) {
+	tbl := table.New("a", "b")
+	tbl.FormatCols(func(s string) string { return "\x1b[38;5;-1m" + s + "\x1b[0m" }, 0)
+	tbl.FormatCols(func(s string) string { return "\x1b[48;5;300m" + s + "\x1b[0m" }, 1)
+	tbl.ColorLevel(table.ColorBasic)
+	tbl.Row("x", "y")
+	want := "\x1b[38;5;-1mx\x1b[0m  \x1b[48;5;300my\x1b[0m"
+	if got := strings.Split(string(tbl.Bytes()), "\n")[1]; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func ExampleTable_Annotate() {
+	t := table.New("region", "revenue")
+	t.Row("north", 1200)
+	t.Annotate(-1, 1, "estimated")
+	t.Row("south", 800)
+	t.Row("east", 950)
+	t.Annotate(-1, 1, "estimated")
+	t.Annotate(-1, 0, "new region")
+	t.Print(os.Stdout)
+	// Output:
+	// region  revenue
+	// north     1200¹
+	// south       800
+	// east²      950¹
+	// ¹ estimated
+	// ² new region
+}
+
+func ExampleTable_FooterAggregates() {
+	t := table.New("endpoint", "latency", "bytes")
+	t.Precision(1, 1, 2)
+	for i, ms := range []float64{12, 15, 11, 40, 13, 14, 120, 12, 16, 18} {
+		t.Row("/api/"+strconv.Itoa(i), ms, 1000+i*100)
+	}
+	t.FooterAggregates(table.P50(1), table.P90(1), table.P99(1), table.StdDev(1), table.P50(2))
+	t.TrailingSpace(table.TrailingTrim)
+	t.Print(os.Stdout)
+	// Output:
+	// endpoint  latency   bytes
+	// /api/0       12.0    1000
+	// /api/1       15.0    1100
+	// /api/2       11.0    1200
+	// /api/3       40.0    1300
+	// /api/4       13.0    1400
+	// /api/5       14.0    1500
+	// /api/6      120.0    1600
+	// /api/7       12.0    1700
+	// /api/8       16.0    1800
+	// /api/9       18.0    1900
+	// -------------------------
+	// p50          14.5  1450.0
+	// p90          48.0
+	// p99         112.8
+	// stddev       32.0
+}
+
+func TestClipWidth(t *testing.T) {
+	tbl := table.New("name", "description")
+	tbl.ColorLevel(table.ColorBasic)
+	tbl.FormatHeader(table.Format(table.Bold))
+	tbl.ClipWidth(10)
+	tbl.Row("a", "a long description")
+	bold := table.Format(table.Bold)
+	want := bold("name") + "  " + "\x1b[1mdesc\x1b[0m\n" + "a     a lo\n"
+	if got := string(tbl.Bytes()); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSetLink(t *testing.T) {
+	tbl := table.New("name", "status")
+	tbl.ColorLevel(table.ColorBasic)
+	tbl.Row("table", "ok")
+	tbl.SetLink(-1, 0, "https://github.com/jayloop/table")
+	tbl.Row("x", "failing")
+	link := table.Link("https://github.com/jayloop/table")
+	want := "name   status\n" + link("table") + "  ok\n" + "x      failing\n"
+	if got := string(tbl.Bytes()); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	var b strings.Builder
+	tbl.PrintMarkdown(&b, table.MarkdownOptions{})
+	if got, want := strings.Split(b.String(), "\n")[2], "| [table](https://github.com/jayloop/table) | ok      |"; got != want {
+		t.Errorf("markdown: got %q, want %q", got, want)
+	}
+	b.Reset()
+	tbl.PrintHTML(&b, table.HTMLOptions{})
+	if want := `<td><a href="https://github.com/jayloop/table">table</a></td>`; !strings.Contains(b.String(), want) {
+		t.Errorf("html: got %q, want it to contain %q", b.String(), want)
+	}
+	tbl.ColorLevel(table.ColorNone)
+	if got, want := string(tbl.Bytes()), "name   status\ntable  ok\nx      failing\n"; got != want {
+		t.Errorf("no color: got %q, want %q", got, want)
+	}
+}
+
+func TestNullValue(t *testing.T) {
+	tbl := table.New("name", "size")
+	tbl.NullValue("NULL", nil, 1)
+	tbl.Row("a", 3)
+	tbl.Row("b", nil)
+	tbl.Row("c", "")
+	tbl.Row("d", sql.NullInt64{Int64: 5, Valid: true})
+	tbl.Row("e", sql.NullInt64{})
+	tbl.Row("f")
+	tbl.Row("g", (*sql.NullString)(nil))
+	tbl.Row("h", (*int)(nil))
+	tbl.FooterAggregates(table.Mean(1))
+	want := "name  size\na     3\nb     NULL\nc     \nd     5\ne     NULL\nf     NULL\ng     NULL\nh     NULL\n" +
+		"----------\nmean  4.00\n"
+	if got := string(tbl.Bytes()); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	b, _ := json.Marshal(tbl)
+	want = `[{"name":"a","size":3},{"name":"b","size":null},{"name":"c","size":""},` +
+		`{"name":"d","size":5},{"name":"e","size":null},{"name":"f","size":null},` +
+		`{"name":"g","size":null},{"name":"h","size":null}]`
+	if got := string(b); got != want {
+		t.Errorf("json: got %s, want %s", got, want)
+	}
+}
+
+func TestSetTheme(t *testing.T) {
+	table.RegisterTheme("test", table.Theme{
+		Header:  table.Format(table.Bold),
+		Stripe:  table.Format(table.Reverse),
+		Border:  table.BorderASCII,
+		Padding: 4,
+	})
+	th, ok := table.LookupTheme("test")
+	if !ok {
+		t.Fatal("registered theme not found")
+	}
+	tbl := table.New("name", "size")
+	tbl.ColorLevel(table.ColorBasic)
+	tbl.Row("a", 1)
+	tbl.Row("b", 2)
+	tbl.SetTheme(th)
+	want := "+------+------+\n" +
+		"| \x1b[1mname\x1b[0m | \x1b[1msize\x1b[0m |\n" +
+		"+------+------+\n" +
+		"| a    |    1 |\n" +
+		"| \x1b[7mb\x1b[0m    |    \x1b[7m2\x1b[0m |\n" +
+		"+------+------+\n"
+	if got := string(tbl.Bytes()); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	tbl.SetTheme(table.Theme{})
+	if got, want := string(tbl.Bytes()), "name  size\na        1\nb        2\n"; got != want {
+		t.Errorf("plain: got %q, want %q", got, want)
+	}
+	for _, name := range []string{"plain", "color", "dark", "light", "solarized"} {
+		if err := tbl.ApplyProfile(table.Profile{Theme: name}); err != nil {
+			t.Errorf("theme %s: %v", name, err)
+		}
+	}
+}
+
+func ExampleTable_Borders_rounded() {
+	t := table.New("name", "size")
+	t.Borders(table.BorderRounded)
+	t.Row("alpha", 1)
+	t.Row("beta", 20)
+	t.Print(os.Stdout)
+	// Output:
+	// ╭───────┬──────╮
+	// │ name  │ size │
+	// ├───────┼──────┤
+	// │ alpha │    1 │
+	// │ beta  │   20 │
+	// ╰───────┴──────╯
+}
+
+func ExampleTable_PrintMarkdown() {
+	t := table.New("name", "size", "note")
+	t.Row("alpha", 1, "a|b")
+	t.Row("beta", 20, "")
+	t.PrintMarkdown(os.Stdout, table.MarkdownOptions{AlignmentMarkers: true})
+	// Output:
+	// | name  | size | note |
+	// | :---- | ---: | :--- |
+	// | alpha | 1    | a\|b |
+	// | beta  | 20   |      |
+}
+
+func ExampleTable_SortUsing() {
+	t := table.New("id", "size")
+	t.SortUsing(table.SortRaw, 1)
+	t.Row("a", 10)
+	t.Row("b", 9)
+	t.Row("c", 100)
+	t.Sort(1)
+	t.Print(os.Stdout)
+	// Output:
+	// id  size
+	// b      9
+	// a     10
+	// c    100
+}
+
+func ExampleTable_PrintCSV() {
+	t := table.New("name", "note", "size")
+	t.Merge(0)
+	t.Row("a", "x, y", 1)
+	t.Row("a", `say "hi"`, 2)
+	t.PrintCSV(os.Stdout, table.CSVOptions{})
+	t.PrintCSV(os.Stdout, table.CSVOptions{Delimiter: ';', NoHeader: true})
+	// Output:
+	// name,note,size
+	// a,"x, y",1
+	// a,"say ""hi""",2
+	// a;x, y;1
+	// a;"say ""hi""";2
+}
+
+func ExampleTable_ApplyProfile() {
+	var p table.Profile
+	json.Unmarshal([]byte(`{"columns": {"path": {"maxWidth": 8}, "size": {"align": "left"}}}`), &p)
+	t := table.New("path", "size")
+	if err := t.ApplyProfile(p); err != nil {
+		fmt.Println(err)
+	}
+	t.Row("/usr/local/bin", 10)
+	t.Row("/tmp", 200)
+	t.Print(os.Stdout)
+	b, _ := json.Marshal(t.Profile())
+	fmt.Println(string(b))
+	// Output:
+	// path      size
+	// /usr/...  10
+	// /tmp      200
+	// {"columns":{"path":{"maxWidth":8},"size":{"align":"left"}}}
+}
+
+func ExampleTable_PrintHTML() {
+	t := table.New("name", "size")
+	t.StyleCols(table.Style{Fg: table.Red, Attrs: []table.CodeANSI{table.Bold}}, 1)
+	t.Row("<a>", 10)
+	t.SetCellMeta(-1, 0, table.Meta{"url": "/a?x=1&y=2"})
+	t.Row("b", 2)
+	t.PrintHTML(os.Stdout, table.HTMLOptions{Classes: map[int]string{0: "name"}})
+	// Output:
+	// <table>
+	// <thead>
+	// <tr><th class="name">name</th><th style="text-align:right">size</th></tr>
+	// </thead>
+	// <tbody>
+	// <tr><td class="name" data-url="/a?x=1&amp;y=2">&lt;a&gt;</td><td style="text-align:right;color:maroon;font-weight:bold">10</td></tr>
+	// <tr><td class="name">b</td><td style="text-align:right;color:maroon;font-weight:bold">2</td></tr>
+	// </tbody>
+	// </table>
+}
+
+func TestFooterHTMLMarkdown(t *testing.T) {
+	tbl := table.New("name", "size")
+	tbl.Row("a", 10)
+	tbl.Row("b", 200)
+	tbl.Footer("total", 210)
+	var b strings.Builder
+	tbl.PrintHTML(&b, table.HTMLOptions{})
+	want := "</tbody>\n<tfoot>\n<tr><td>total</td><td style=\"text-align:right\">210</td></tr>\n</tfoot>\n</table>\n"
+	if !strings.HasSuffix(b.String(), want) {
+		t.Errorf("PrintHTML: got %q, want it to end with %q", b.String(), want)
+	}
+	b.Reset()
+	tbl.PrintMarkdown(&b, table.MarkdownOptions{})
+	want = "| name  | size |\n" +
+		"| ----- | ---- |\n" +
+		"| a     | 10   |\n" +
+		"| b     | 200  |\n" +
+		"| total | 210  |\n"
+	if b.String() != want {
+		t.Errorf("PrintMarkdown: got\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestPrintHTMLMetaKeys(t *testing.T) {
+	tbl := table.New("name")
+	tbl.Row("a")
+	tbl.SetRowMeta(-1, table.Meta{"id": "1", `x="y"><script>`: "2", "a b": "3", "": "4", "Row.Key_2": "5"})
+	var b strings.Builder
+	tbl.PrintHTML(&b, table.HTMLOptions{})
+	if want := `<tr data-row.key_2="5" data-id="1">`; !strings.Contains(b.String(), want) {
+		t.Errorf("got %q, want it to contain %q", b.String(), want)
+	}
+}
+
+func TestAppendTracked(t *testing.T) {
+	eu := table.New("pod", "restarts")
+	eu.TrackRows(0, nil, nil, nil)
+	s := eu.Snapshot()
+	eu.Row("web-1", 0)
+	eu.Row("web-2", 3)
+	eu.Bytes()
+	eu.Restore(s)
+	eu.Row("web-1", 1)
+	eu.Annotate(-1, 1, "restarted by the operator")
+	eu.SetLink(-1, 0, "https://example.com/web-1")
+	eu.Bytes() // appends web-2 as a removed row
+	all := table.New("pod", "restarts")
+	all.Append(eu, "eu")
+	if got := all.Len(); got != 1 {
+		t.Errorf("got %d rows, want 1", got)
+	}
+	if got, want := all.Annotation(0, 1), "restarted by the operator"; got != want {
+		t.Errorf("got annotation %q, want %q", got, want)
+	}
+	var b strings.Builder
+	all.PrintMarkdown(&b, table.MarkdownOptions{})
+	if want := "[web-1](https://example.com/web-1)"; !strings.Contains(b.String(), want) {
+		t.Errorf("got %q, want it to contain %q", b.String(), want)
+	}
+}
+
+func ExampleTable_Append() {
+	eu := table.New("pod", "restarts")
+	eu.Row("web-1", 0)
+	eu.Row("web-2", 3)
+	us := table.New("pod", "restarts")
+	us.Row("web-1", 1)
+
+	t := table.New("pod", "restarts")
+	cluster := t.SourceColumn("cluster")
+	t.Append(eu, "eu-west")
+	t.Append(us, "us-east")
+	t.Merge(cluster)
+	t.TrailingSpace(table.TrailingTrim)
+	t.Sort(cluster, 0)
+	t.Print(os.Stdout)
+	fmt.Println(t.RowSource(-1))
+	// Output:
+	// pod    restarts  cluster
+	// web-1         0  eu-west
+	// web-2         3
+	// web-1         1  us-east
+	// us-east
+}
+
+func ExampleTruncation() {
+	t := table.New("path", "id", "name")
+	t.MaxWidth(16, 0, 1, 2)
+	t.Truncator(table.Truncation(table.TruncateMiddle, "…"), 0)
+	t.Truncator(table.Truncation(table.TruncateStart, "…"), 1)
+	t.Truncator(table.Truncation(table.TruncateEnd, "~"), 2)
+	t.Row("/usr/local/share/doc/table/README.md", "4f9c2e7a1b3d5f60a8c1", "a rather long name")
+	t.Print(os.Stdout)
+	// Output:
+	// path              id                name
+	// /usr/loc…ADME.md  …e7a1b3d5f60a8c1  a rather long n~
+}
+
+func ExampleTruncateCount() {
+	t := table.New("id", "message")
+	t.MaxWidth(16, 1)
+	t.Truncator(table.TruncateCount, 1)
+	t.Row(1, "connection refused by upstream server")
+	t.Print(os.Stdout)
+	fmt.Println(t.ExpandedCell(0, 1))
+	// Output:
+	// id  message
+	//  1  connection…(+27)
+	// connection refused by upstream server
+}
+
+func ExampleTable_PrintJSON() {
+	t := table.New("name", "size", "ok")
+	t.Precision(1, 1)
+	t.Row("a", 1.25, true)
+	t.Row(`b "x"`, 2, false)
+	t.PrintJSON(os.Stdout)
+	// Output:
+	// [{"name":"a","size":1.25,"ok":true},{"name":"b \"x\"","size":2,"ok":false}]
+}
+
+func ExampleTable_HeaderAbbreviations() {
+	t := table.New("NAMESPACE", "NAME", "RESTARTS")
+	t.HeaderAbbreviations(map[string]string{"NAMESPACE": "NS", "RESTARTS": "RS"})
+	t.TotalMaxWidth(34)
+	t.Row("kube", "coredns-5d78c9869d", 0)
+	t.Print(os.Stdout)
+	t.Row("kube", "etcd", 0)
+	t.TotalMaxWidth(30)
+	t.Print(os.Stdout)
+	// Output:
+	// NAMESPACE  NAME                RS
+	// kube       coredns-5d78c9869d   0
+	// NS    NAME                RS
+	// kube  coredns-5d78c9869d   0
+	// kube  etcd                 0
+}
+
+func TestPrintTSV(t *testing.T) {
+	tbl := table.New("name", "note", "size")
+	tbl.FormatCols(table.Format(table.Red), 0)
+	tbl.Row("a", "x\ty", 1)
+	tbl.Row("b", "", 20)
+	var b strings.Builder
+	tbl.PrintTSV(&b)
+	if got, want := b.String(), "name\tnote\tsize\na\tx y\t1\nb\t\t20\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func ExampleTable_Footer() {
+	t := table.New("service", "requests")
+	t.Row("web", 300)
+	t.Row("api", 600)
+	t.Footer("total", 900)
+	t.Sort(0)
+	t.Print(os.Stdout)
+	// Output:
+	// service  requests
+	// api           600
+	// web           300
+	// -----------------
+	// total         900
+}
+
+func TestPrintCSVNative(t *testing.T) {
+	tbl := table.New("name", "size_bytes", "ratio", "ok", "at")
+	tbl.AutoFormat()
+	tbl.Precision(1, 2)
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tbl.Row("a", 1536, 0.125, true, at)
+	var b strings.Builder
+	tbl.PrintCSV(&b, table.CSVOptions{})
+	want := "name,size_bytes,ratio,ok,at\na,1536,0.125,true,2024-05-01T12:00:00Z\n"
+	if got := b.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRaw(t *testing.T) {
+	tbl := table.New("name", "link", "n")
+	tbl.ColorLevel(table.ColorNone)
+	tbl.FormatCols(table.Format(table.Red), 1)
+	link := "\x1b]8;;https://example.com\x1b\\docs\x1b]8;;\x1b\\"
+	tbl.Row("a", table.Raw(link, 4), 1)
+	tbl.Row("b", "long text", 2)
+	want := "name  link       n\n" +
+		"a     " + link + "       1\n" +
+		"b     long text  2\n"
+	if got := string(tbl.Bytes()); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func ExampleStack() {
+	a := table.New("host", "cpu")
+	a.Row("web-1", 12)
+	b := table.New("host", "disk")
+	b.Row("db-1", 80)
+	t := table.Stack(a, b)
+	t.TrailingSpace(table.TrailingTrim)
+	t.Print(os.Stdout)
+	fmt.Println(t.ColumnOrigins(0), t.ColumnOrigins(2))
+	// Output:
+	// host   cpu  disk
+	// web-1   12
+	// db-1          80
+	// [0 1] [1]
+}
+
+func ExampleTable_Title() {
+	t := table.New("name", "region", "size")
+	t.Title("hosts")
+	t.Row("web-1", "eu-west", 10)
+	t.Print(os.Stdout)
+	t.Title("all hosts in every region")
+	t.TitleStyle(table.Left, nil, true)
+	t.Print(os.Stdout)
+	// Output:
+	//         hosts
+	// name   region   size
+	// web-1  eu-west    10
+	// all hosts in every
+	// region
+	// name   region   size
+	// web-1  eu-west    10
+}
+
+func ExampleReport() {
+	eu := table.New("host", "cpu")
+	eu.Row("web-1", 12)
+	us := table.New("host", "cpu")
+	us.Row("database-1", 7)
+	r := table.NewReport()
+	r.Add("eu-west", eu)
+	r.Add("us-east", us)
+	r.Print(os.Stdout)
+	// Output:
+	// eu-west
+	// host        cpu
+	// web-1        12
+	//
+	// us-east
+	// host        cpu
+	// database-1    7
+}
+
+func TestReportLineEndings(t *testing.T) {
+	eu := table.New("host")
+	eu.Row("web-1")
+	us := table.New("host")
+	us.Row("db-1")
+	for _, tbl := range []*table.Table{eu, us} {
+		tbl.LineEnding("\r\n")
+		tbl.ClipWidth(5)
+		tbl.ColorLevel(table.ColorNone)
+	}
+	eu.FinalNewline(false)
+	r := table.NewReport()
+	r.Add("\x1b[1meu-west\x1b[0m", eu)
+	r.Add("us-east", us)
+	var b strings.Builder
+	r.Print(&b)
+	if got, want := b.String(), "eu-we\r\nhost\r\nweb-1\r\n\r\nus-ea\r\nhost\r\ndb-1\r\n"; got != want {
+		t.Errorf("Print: got %q, want %q", got, want)
+	}
+	b.Reset()
+	r.PrintMarkdown(&b, table.MarkdownOptions{})
+	want := "## eu-west\r\n\r\n| host  |\r\n| ----- |\r\n| web-1 |\r\n\r\n" +
+		"## us-east\r\n\r\n| host |\r\n| ---- |\r\n| db-1 |\r\n"
+	if got := b.String(); got != want {
+		t.Errorf("PrintMarkdown: got %q, want %q", got, want)
+	}
+	b.Reset()
+	r.PrintHTML(&b, table.HTMLOptions{})
+	if got := b.String(); strings.Contains(strings.ReplaceAll(got, "\r\n", ""), "\n") || !strings.HasSuffix(got, "</table>\r\n</section>\r\n") {
+		t.Errorf("PrintHTML: got %q, want CRLF line endings", got)
+	}
+}
+
+func ExampleTable_HeaderRule() {
+	t := table.New("name", "size")
+	t.HeaderRule('-')
+	t.Row("alpha", 1)
+	t.Print(os.Stdout)
+	// Output:
+	// name   size
+	// -----  ----
+	// alpha     1
+}
+
+func ExampleTable_Parse() {
+	t := table.New("request", "latency")
+	t.Parse(table.ParseUnits(map[string]float64{"ms": 1, "s": 1000}), 1)
+	t.Precision(-1, 1)
+	t.Row("a", "1.2s")
+	t.Row("b", "250ms")
+	t.Row("c", "95ms")
+	t.Sort(1)
+	t.Print(os.Stdout)
+	// Output:
+	// request  latency
+	// c             95
+	// b            250
+	// a           1200
+}
+
+func TestStripeRows(t *testing.T) {
+	tbl := table.New("name")
+	tbl.ColorLevel(table.ColorBasic)
+	odd := table.Format(table.Reverse)
+	tbl.StripeRows(nil, odd)
+	tbl.Row("c")
+	tbl.Row("a")
+	tbl.Row("b")
+	tbl.Sort(0)
+	want := "name\na\n" + odd("b") + "\nc\n"
+	if got := string(tbl.Bytes()); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func ExampleTable_SeparateEvery() {
+	t := table.New("n", "square")
+	t.SeparateEvery(2)
+	for i := 1; i <= 5; i++ {
+		t.Row(i, i*i)
+	}
+	t.Print(os.Stdout)
+	// Output:
+	// n  square
+	// 1       1
+	// 2       4
+	// ---------
+	// 3       9
+	// 4      16
+	// ---------
+	// 5      25
+}
+
+func ExampleTable_GroupByTime() {
+	t := table.New("time", "status", "bytes")
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	t.Row(at.Add(5*time.Minute), 200, 512)
+	t.Row(at.Add(20*time.Minute), 500, 128)
+	t.Row(at.Add(70*time.Minute), 200, 1024)
+	s := t.GroupByTime(0, time.Hour, table.Sum(2), table.Max(2))
+	s.Print(os.Stdout)
+	// Output:
+	// time              count  sum(bytes)  max(bytes)
+	// 2024-05-01 12:00      2      640.00      512.00
+	// 2024-05-01 13:00      1     1024.00     1024.00
+}
+
+func TestGroupByTimeInvalidColumn(t *testing.T) {
+	tbl := table.New("time", "bytes")
+	tbl.Row(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), 512)
+	if s := tbl.GroupByTime(5, time.Hour, table.Sum(1)); string(s.Bytes()) != "\n" {
+		t.Errorf("invalid column: got %q, want an empty table", s.Bytes())
+	}
+	s := tbl.GroupByTime(0, time.Hour, table.Sum(1), table.Max(7))
+	if got, want := string(s.Bytes()), "time              count  sum(bytes)\n2024-05-01 12:00      1      512.00\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestGroupByTimeLocation(t *testing.T) {
+	syd := time.FixedZone("AEST", 10*60*60)
+	tbl := table.New("time", "bytes")
+	tbl.Row(time.Date(2024, 4, 29, 2, 0, 0, 0, syd), 1)
+	tbl.Row(time.Date(2024, 5, 1, 1, 0, 0, 0, syd), 2)
+	tbl.Row(time.Date(2024, 5, 1, 23, 0, 0, 0, syd), 3)
+	tbl.Row(time.Date(2024, 5, 5, 22, 0, 0, 0, syd), 4)
+	s := tbl.GroupByTime(0, 24*time.Hour, table.Sum(1))
+	want := "time        count  sum(bytes)\n" +
+		"2024-04-29      1        1.00\n" +
+		"2024-05-01      2        5.00\n" +
+		"2024-05-05      1        4.00\n"
+	if got := string(s.Bytes()); got != want {
+		t.Errorf("days: got %q, want %q", got, want)
+	}
+	s = tbl.GroupByTime(0, 7*24*time.Hour, table.Sum(1))
+	want = "time        count  sum(bytes)\n" +
+		"2024-04-29      4       10.00\n"
+	if got := string(s.Bytes()); got != want {
+		t.Errorf("weeks: got %q, want %q", got, want)
+	}
+}
+
+func ExampleTable_FreezeColumns() {
+	t := table.New("host", "cpu", "memory", "disk", "network")
+	t.TotalMaxWidth(24)
+	t.FreezeColumns(1)
+	t.Row("web-1", "12%", "1.2 GB", "40 GB", "12 Mb/s")
+	t.Row("db-1", "64%", "8.0 GB", "500 GB", "3 Mb/s")
+	t.Print(os.Stdout)
+	fmt.Println(t.Layout().Columns)
+	// Output:
+	// host   cpu  memory
+	// web-1  12%  1.2 GB
+	// db-1   64%  8.0 GB
+	//
+	// host   disk    network
+	// web-1  40 GB   12 Mb/s
+	// db-1   500 GB  3 Mb/s
+	// [host cpu memory host disk network]
+}
+
+func TestFreezeColumnsLayout(t *testing.T) {
+	tbl := table.New("host", "description", "owner")
+	tbl.TotalMaxWidth(20)
+	tbl.FreezeColumns(1)
+	tbl.Borders(table.BorderASCII)
+	tbl.Row("web-1", "a long description", "alice")
+	var d table.Diagnostics
+	tbl.OnDiagnostics(func(diag table.Diagnostics) { d = diag })
+	b := tbl.Bytes()
+	l := tbl.Layout()
+	if got := strings.Count(string(b), "\n"); got != l.Lines {
+		t.Errorf("printed %d lines, layout has %d", got, l.Lines)
+	}
+	if l.Width > 20 {
+		t.Errorf("got width %d, want at most 20", l.Width)
+	}
+	if d.Truncated[1] != 1 || d.Truncated[0] != 0 || d.Overflow != 1 {
+		t.Errorf("got truncated %v and overflow %d, want only the description truncated", d.Truncated, d.Overflow)
+	}
+}
+
+func TestAutoFit(t *testing.T) {
+	defer os.Setenv("COLUMNS", os.Getenv("COLUMNS"))
+	os.Setenv("COLUMNS", "20")
+	tbl := table.New("name", "description")
+	tbl.AutoFit()
+	tbl.Row("a", "a description longer than the terminal")
+	if got, want := string(tbl.Bytes()), "name  description\na     a descripti...\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,102 @@
+// Package tabletest generates deterministic random tables for benchmarks and fuzzing of table renderers.
+package tabletest
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+
+	"github.com/jayloop/table"
+)
+
+// Options sets the shape and content of generated tables.
+type Options struct {
+	// Rows and Cols set the size of the table.
+	Rows, Cols int
+	// MaxLen is the max length in characters of generated strings. The default is 12.
+	MaxLen int
+	// Unicode includes accented, wide East Asian and emoji characters in strings.
+	Unicode bool
+	// Long makes about one in ten strings up to ten times longer than MaxLen.
+	Long bool
+	// Extreme includes extreme numbers like math.MaxInt64, -0, NaN and infinities.
+	Extreme bool
+}
+
+var (
+	ascii   = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 -_./")
+	unicode = []rune("åäöéüñçß日本語中文한국어😀🚀✓→─│")
+	extreme = []interface{}{
+		int64(math.MaxInt64), int64(math.MinInt64), uint64(math.MaxUint64), math.MaxFloat64, math.SmallestNonzeroFloat64,
+		math.Copysign(0, -1), math.NaN(), math.Inf(1), math.Inf(-1),
+	}
+)
+
+// New returns a table of random values generated from seed. The same seed and options
+// always give the same table. Columns hold strings, ints or floats, chosen by column index.
+func New(seed int64, o Options) *table.Table {
+	headers, rows := Values(seed, o)
+	t := table.New(headers...)
+	t.Grow(len(rows))
+	for _, r := range rows {
+		t.Row(r...)
+	}
+	return t
+}
+
+// Values returns the headers and row values of the table New makes from seed and o.
+func Values(seed int64, o Options) ([]string, [][]interface{}) {
+	if o.MaxLen <= 0 {
+		o.MaxLen = 12
+	}
+	g := generator{rand: rand.New(rand.NewSource(seed)), o: o}
+	headers := make([]string, o.Cols)
+	for i := range headers {
+		headers[i] = "col" + strconv.Itoa(i)
+	}
+	rows := make([][]interface{}, o.Rows)
+	for j := range rows {
+		r := make([]interface{}, o.Cols)
+		for i := range r {
+			r[i] = g.value(i)
+		}
+		rows[j] = r
+	}
+	return headers, rows
+}
+
+type generator struct {
+	rand *rand.Rand
+	o    Options
+}
+
+// value returns a random value for column i.
+func (g *generator) value(i int) interface{} {
+	if g.o.Extreme && i%3 != 0 && g.rand.Intn(10) == 0 {
+		return extreme[g.rand.Intn(len(extreme))]
+	}
+	switch i % 3 {
+	case 1:
+		return g.rand.Intn(100000) - 50000
+	case 2:
+		return g.rand.NormFloat64() * 1000
+	}
+	return g.string()
+}
+
+// string returns a random string.
+func (g *generator) string() string {
+	n := g.rand.Intn(g.o.MaxLen + 1)
+	if g.o.Long && g.rand.Intn(10) == 0 {
+		n *= 10
+	}
+	s := make([]rune, n)
+	for k := range s {
+		if g.o.Unicode && g.rand.Intn(4) == 0 {
+			s[k] = unicode[g.rand.Intn(len(unicode))]
+		} else {
+			s[k] = ascii[g.rand.Intn(len(ascii))]
+		}
+	}
+	return string(s)
+}
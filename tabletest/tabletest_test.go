@@ -0,0 +1,31 @@
+package tabletest_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/jayloop/table/tabletest"
+)
+
+func TestNewDeterministic(t *testing.T) {
+	o := tabletest.Options{Rows: 50, Cols: 6, Unicode: true, Long: true, Extreme: true}
+	a, b := tabletest.New(42, o).Bytes(), tabletest.New(42, o).Bytes()
+	if !bytes.Equal(a, b) {
+		t.Error("tables generated from the same seed differ")
+	}
+	if c := tabletest.New(43, o).Bytes(); bytes.Equal(a, c) {
+		t.Error("tables generated from different seeds are equal")
+	}
+	if got := bytes.Count(a, []byte("\n")); got != 51 {
+		t.Errorf("got %d lines, want 51", got)
+	}
+}
+
+func BenchmarkPrint(b *testing.B) {
+	tbl := tabletest.New(1, tabletest.Options{Rows: 1000, Cols: 8, Unicode: true})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		tbl.Print(ioutil.Discard)
+	}
+}
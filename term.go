@@ -0,0 +1,101 @@
+package table
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Tone is the brightness of a terminal background.
+type Tone int
+
+// Terminal background tones
+const (
+	ToneUnknown Tone = iota
+	ToneDark
+	ToneLight
+)
+
+// DetectTone detects whether the terminal has a dark or a light background.
+// It first looks at the COLORFGBG environment variable set by some terminals,
+// then queries the terminal for its background color with an OSC 11 escape sequence.
+// ToneUnknown is returned if standard output is not a terminal or the terminal does not answer.
+func DetectTone() Tone {
+	if t := toneFromColorFgBg(os.Getenv("COLORFGBG")); t != ToneUnknown {
+		return t
+	}
+	if !isTerminal(os.Stdout) {
+		return ToneUnknown
+	}
+	return queryTone()
+}
+
+// ToneHeaderFormat returns a header format readable on a terminal background of the given tone,
+// to be used with DefaultHeaderFormat or FormatHeader. Unknown tones are treated as dark.
+func ToneHeaderFormat(t Tone) FormatFunc {
+	if t == ToneLight {
+		return Format(Blue, Bold)
+	}
+	return Format(HiYellow, Bold)
+}
+
+// AutoFit limits the width of the table to the width of the terminal when printing, as by
+// TotalMaxWidth, so that lines never wrap. The width is read from standard output, or from the
+// COLUMNS environment variable if it is not a terminal. If neither is available, the table is
+// printed as usual. A smaller limit set by TotalMaxWidth is kept.
+func (t *Table) AutoFit() {
+	t.autoFit = true
+}
+
+// terminalWidth returns the width of the terminal on standard output, or 0 if unknown.
+func terminalWidth() int {
+	if w := terminalSize(os.Stdout); w > 0 {
+		return w
+	}
+	w, err := strconv.Atoi(os.Getenv("COLUMNS"))
+	if err != nil || w < 0 {
+		return 0
+	}
+	return w
+}
+
+// toneFromColorFgBg parses a COLORFGBG value like "15;0", where the last field is the background color.
+func toneFromColorFgBg(s string) Tone {
+	if s == "" {
+		return ToneUnknown
+	}
+	bg, err := strconv.Atoi(s[strings.LastIndexByte(s, ';')+1:])
+	switch {
+	case err != nil:
+		return ToneUnknown
+	case bg == 7 || (bg >= 9 && bg <= 15):
+		return ToneLight
+	}
+	return ToneDark
+}
+
+// toneFromOSC parses an OSC 11 reply like "\x1b]11;rgb:ffff/ffff/ffff\x07".
+func toneFromOSC(reply string) Tone {
+	i := strings.Index(reply, "rgb:")
+	if i < 0 {
+		return ToneUnknown
+	}
+	s := strings.TrimRight(reply[i+4:], "\x07\x1b\\")
+	parts := strings.Split(s, "/")
+	if len(parts) != 3 {
+		return ToneUnknown
+	}
+	var rgb [3]float64
+	for k, p := range parts {
+		n, err := strconv.ParseUint(p, 16, 16)
+		if err != nil || len(p) == 0 || len(p) > 4 {
+			return ToneUnknown
+		}
+		rgb[k] = float64(n) / float64(uint64(1)<<(4*uint(len(p)))-1)
+	}
+	// relative luminance
+	if 0.2126*rgb[0]+0.7152*rgb[1]+0.0722*rgb[2] > 0.5 {
+		return ToneLight
+	}
+	return ToneDark
+}
@@ -0,0 +1,26 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !windows
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!windows
+
+package table
+
+import "os"
+
+// isTerminal reports whether f is a terminal. It is not supported on this platform.
+func isTerminal(f *os.File) bool {
+	return false
+}
+
+// enableEscapes prepares f for printing escape sequences. Nothing is needed on this platform.
+func enableEscapes(f *os.File) bool {
+	return true
+}
+
+// terminalSize returns the width in characters of terminal f. It is not supported on this platform.
+func terminalSize(f *os.File) int {
+	return 0
+}
+
+// queryTone asks the terminal for its background color. It is not supported on this platform.
+func queryTone() Tone {
+	return ToneUnknown
+}
@@ -0,0 +1,87 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package table
+
+import (
+	"os"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+func getTermios(fd uintptr) (*syscall.Termios, error) {
+	var t syscall.Termios
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlGetTermios, uintptr(unsafe.Pointer(&t))); errno != 0 {
+		return nil, errno
+	}
+	return &t, nil
+}
+
+func setTermios(fd uintptr, t *syscall.Termios) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlSetTermios, uintptr(unsafe.Pointer(t))); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// isTerminal reports whether f is a terminal.
+func isTerminal(f *os.File) bool {
+	_, err := getTermios(f.Fd())
+	return err == nil
+}
+
+// enableEscapes prepares f for printing escape sequences. Terminals interpret them by default.
+func enableEscapes(f *os.File) bool {
+	return true
+}
+
+// terminalSize returns the width in characters of terminal f, or 0 if f is not a terminal.
+func terminalSize(f *os.File) int {
+	var ws struct {
+		Row, Col, Xpixel, Ypixel uint16
+	}
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&ws))); errno != 0 {
+		return 0
+	}
+	return int(ws.Col)
+}
+
+// queryTone asks the controlling terminal for its background color.
+// The terminal is put in raw mode while waiting at most a few tenths of a second for the reply.
+func queryTone() Tone {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return ToneUnknown
+	}
+	defer tty.Close()
+	fd := tty.Fd()
+	old, err := getTermios(fd)
+	if err != nil {
+		return ToneUnknown
+	}
+	raw := *old
+	raw.Lflag &^= syscall.ICANON | syscall.ECHO
+	raw.Cc[syscall.VMIN] = 0
+	raw.Cc[syscall.VTIME] = 2
+	if err := setTermios(fd, &raw); err != nil {
+		return ToneUnknown
+	}
+	defer setTermios(fd, old)
+	if _, err := tty.WriteString("\x1b]11;?\x07"); err != nil {
+		return ToneUnknown
+	}
+	var reply []byte
+	buf := make([]byte, 64)
+	for len(reply) < 256 {
+		n, err := tty.Read(buf)
+		if n == 0 || err != nil {
+			break
+		}
+		reply = append(reply, buf[:n]...)
+		if s := string(reply); strings.HasSuffix(s, "\x07") || strings.HasSuffix(s, "\x1b\\") {
+			break
+		}
+	}
+	return toneFromOSC(string(reply))
+}
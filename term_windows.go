@@ -0,0 +1,65 @@
+//go:build windows
+// +build windows
+
+package table
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	kernel32                       = syscall.NewLazyDLL("kernel32.dll")
+	procGetConsoleMode             = kernel32.NewProc("GetConsoleMode")
+	procSetConsoleMode             = kernel32.NewProc("SetConsoleMode")
+	procGetConsoleScreenBufferInfo = kernel32.NewProc("GetConsoleScreenBufferInfo")
+)
+
+// enableVirtualTerminalProcessing is the console mode flag interpreting escape sequences.
+const enableVirtualTerminalProcessing = 0x0004
+
+// consoleMode returns the mode of console f, or false if f is not a console.
+func consoleMode(f *os.File) (uint32, bool) {
+	var mode uint32
+	r, _, _ := procGetConsoleMode.Call(f.Fd(), uintptr(unsafe.Pointer(&mode)))
+	return mode, r != 0
+}
+
+// isTerminal reports whether f is a console.
+func isTerminal(f *os.File) bool {
+	_, ok := consoleMode(f)
+	return ok
+}
+
+// enableEscapes prepares f for printing escape sequences, enabling virtual terminal processing
+// if f is a console. It returns false for older consoles not supporting it, on which escape
+// sequences would print as garbage.
+func enableEscapes(f *os.File) bool {
+	mode, ok := consoleMode(f)
+	if !ok || mode&enableVirtualTerminalProcessing != 0 {
+		return true
+	}
+	r, _, _ := procSetConsoleMode.Call(f.Fd(), uintptr(mode|enableVirtualTerminalProcessing))
+	return r != 0
+}
+
+// terminalSize returns the width in characters of console f, or 0 if f is not a console.
+func terminalSize(f *os.File) int {
+	var info struct {
+		Size, CursorPosition     struct{ X, Y int16 }
+		Attributes               uint16
+		Left, Top, Right, Bottom int16
+		MaximumWindowSize        struct{ X, Y int16 }
+	}
+	r, _, _ := procGetConsoleScreenBufferInfo.Call(f.Fd(), uintptr(unsafe.Pointer(&info)))
+	if r == 0 {
+		return 0
+	}
+	return int(info.Right-info.Left) + 1
+}
+
+// queryTone asks the terminal for its background color. It is not supported on this platform.
+func queryTone() Tone {
+	return ToneUnknown
+}
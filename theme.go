@@ -0,0 +1,95 @@
+package table
+
+// A Theme bundles the settings of the appearance of a table, applied at once by SetTheme.
+type Theme struct {
+	// Header formats the column headers, or is the format set by DefaultHeaderFormat if nil.
+	Header FormatFunc
+	// Rows formats the cells of all rows and Stripe those of every other row, starting with the
+	// second, for zebra striping. Like StripeRows, they only apply to cells without any other format.
+	Rows, Stripe FormatFunc
+	// Border is the style of the lines around the cells and BorderFormat their format.
+	Border       BorderStyle
+	BorderFormat FormatFunc
+	// Padding is the number of whitespaces between columns, or 2 if 0.
+	Padding int
+}
+
+// themes maps the names of the registered themes to them.
+var themes = map[string]Theme{
+	"plain": {},
+	"color": {
+		Header: Format(HiYellow, Bold),
+	},
+	"dark": {
+		Header:       Format(HiCyan, Bold),
+		Stripe:       Format256Bg(236),
+		Border:       BorderRounded,
+		BorderFormat: Format(HiBlack),
+	},
+	"light": {
+		Header:       Format(Blue, Bold),
+		Stripe:       Format256Bg(254),
+		Border:       BorderBox,
+		BorderFormat: Format(HiBlack),
+	},
+	"solarized": {
+		Header:       FormatRGB(0xb5, 0x89, 0x00),
+		Rows:         FormatRGB(0x83, 0x94, 0x96),
+		Stripe:       FormatRGBBg(0x07, 0x36, 0x42),
+		Border:       BorderRounded,
+		BorderFormat: FormatRGB(0x58, 0x6e, 0x75),
+	},
+}
+
+// RegisterTheme adds a theme that can be looked up by name, including by profiles, replacing any
+// theme of the same name. The built-in themes are "plain", "color", "dark", "light" and "solarized".
+func RegisterTheme(name string, th Theme) {
+	mu.Lock()
+	themes[name] = th
+	mu.Unlock()
+}
+
+// LookupTheme returns the theme registered with the given name, if any.
+func LookupTheme(name string) (Theme, bool) {
+	mu.RLock()
+	th, ok := themes[name]
+	mu.RUnlock()
+	return th, ok
+}
+
+// SetTheme applies all settings of th to the table, replacing those set before by FormatHeader,
+// StripeRows, Borders, FormatBorder and Padding.
+func (t *Table) SetTheme(th Theme) {
+	header := th.Header
+	if header == nil {
+		mu.RLock()
+		header = defaultHeaderFormat
+		mu.RUnlock()
+	}
+	t.FormatHeader(header)
+	odd := th.Rows
+	if th.Stripe != nil {
+		odd = chain(th.Rows, th.Stripe)
+	}
+	t.StripeRows(th.Rows, odd)
+	t.Borders(th.Border)
+	t.FormatBorder(th.BorderFormat)
+	if th.Padding > 0 {
+		t.Padding(th.Padding)
+	} else {
+		t.Padding(2)
+	}
+}
+
+// chain returns a format function applying inner then outer, either of which may be nil.
+func chain(inner, outer FormatFunc) FormatFunc {
+	switch {
+	case inner == nil:
+		return outer
+	case outer == nil:
+		return inner
+	}
+	return func(s string) string {
+		return outer(inner(s))
+	}
+}
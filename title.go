@@ -0,0 +1,95 @@
+package table
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Title sets a caption printed above the header, centered on the table width.
+// Titles wider than the table are truncated, see TitleStyle.
+func (t *Table) Title(title string) {
+	t.title = title
+}
+
+// TitleStyle sets the alignment of the title, the format applied to it, which may be nil,
+// and whether titles wider than the table are wrapped onto several lines instead of truncated.
+func (t *Table) TitleStyle(a Alignment, fn FormatFunc, wrap bool) {
+	t.titleAlign = a
+	t.titleFormat = fn
+	t.titleWrap = wrap
+}
+
+// titleLines returns the lines of the title laid out for a table w characters wide.
+func (t *Table) titleLines(w int) []string {
+	if t.title == "" {
+		return nil
+	}
+	var lines []string
+	if t.titleWrap {
+		lines = wrapWords(t.title, w)
+	} else {
+		lines = []string{truncate(t.title, w)}
+	}
+	for n, l := range lines {
+		space := w - stringWidth(l)
+		switch t.titleAlign {
+		case Left:
+			space = 0
+		case Right:
+		default:
+			space /= 2
+		}
+		if t.titleFormat != nil {
+			l = t.titleFormat(l)
+		}
+		lines[n] = strings.Repeat(" ", max(space, 0)) + l
+	}
+	return lines
+}
+
+// printTitle prints the title, if any.
+func (t *Table) printTitle(p *printer, v *view) {
+	for _, l := range t.titleLines(t.width(v)) {
+		p.writeString(l)
+		p.endLine()
+	}
+}
+
+// wrapWords splits s into lines of at most w terminal cells, breaking at spaces where possible.
+func wrapWords(s string, w int) []string {
+	if w < 1 {
+		w = 1
+	}
+	var lines []string
+	line, n := "", 0
+	for _, word := range strings.Fields(s) {
+		ww := stringWidth(word)
+		if n > 0 && n+1+ww > w {
+			lines = append(lines, line)
+			line, n = "", 0
+		}
+		if n > 0 {
+			line, n = line+" ", n+1
+		}
+		line, n = line+word, n+ww
+		for n > w {
+			i, styled := cutIndex(line, w)
+			if stringWidth(line[:i]) == 0 {
+				// a wide character in a single cell column
+				_, size := utf8.DecodeRuneInString(line[i:])
+				i += size
+			}
+			head, rest := line[:i], line[i:]
+			if styled {
+				head, rest = head+resetANSI, escapes(head)+rest
+			}
+			lines = append(lines, head)
+			line = rest
+			n = stringWidth(line)
+		}
+	}
+	if n > 0 || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
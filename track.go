@@ -0,0 +1,148 @@
+package table
+
+// tracker holds the state of TrackRows. It is shared by the snapshots of a table, so that it
+// is kept by Restore.
+type tracker struct {
+	key                     int
+	added, changed, removed FormatFunc
+	printed                 bool                 // printed since the last Restore
+	prev                    []row                // rows when Restore was last called
+	new                     map[int]bool         // rows added, by row index
+	changedCells            map[int]map[int]bool // columns changed, by row index
+	ghosts                  int                  // removed rows appended to the table
+}
+
+// TrackRows identifies rows by their cell in column key across the refreshes of a table that
+// is rebuilt and printed repeatedly, like in a watch loop that restores a snapshot and then
+// adds the current rows before each print. Compared to the rows before the last Restore, rows
+// with a new key are formatted with added and cells that changed with changed. Rows that are
+// gone are printed once more at the end of the table, formatted with removed, but are not
+// exported. Any of the formats may be nil. Call TrackRows before taking the snapshot, so that
+// the tracked rows are kept by Restore. Nothing is tracked until the table is first printed.
+func (t *Table) TrackRows(key int, added, changed, removed FormatFunc) {
+	if !t.validCol(key) {
+		return
+	}
+	t.invalidate()
+	t.tracker = &tracker{key: key, added: added, changed: changed, removed: removed}
+}
+
+// saveTracked keeps the current rows of the table for comparison after a Restore.
+func (t *Table) saveTracked() {
+	tr := t.tracker
+	if tr == nil || !tr.printed {
+		return
+	}
+	tr.printed = false
+	tr.prev = tr.prev[:0]
+	for _, r := range t.rows {
+		if !r.blank && !r.removed {
+			tr.prev = append(tr.prev, r)
+		}
+	}
+}
+
+// track compares the rows of the table with the tracked rows and appends the removed rows.
+func (t *Table) track() {
+	tr := t.tracker
+	if tr == nil {
+		return
+	}
+	t.dropRemoved()
+	tr.printed = true
+	tr.new, tr.changedCells = make(map[int]bool), make(map[int]map[int]bool)
+	if tr.prev == nil {
+		return
+	}
+	prev := make(map[string]*row, len(tr.prev))
+	for j := range tr.prev {
+		prev[tr.prev[j].cell(tr.key)] = &tr.prev[j]
+	}
+	seen := make(map[string]bool, len(t.rows))
+	for j := range t.rows {
+		r := &t.rows[j]
+		if r.blank {
+			continue
+		}
+		k := r.cell(tr.key)
+		seen[k] = true
+		p, ok := prev[k]
+		if !ok {
+			tr.new[j] = true
+			continue
+		}
+		for i := 0; i < t.columns; i++ {
+			if r.cell(i) == p.cell(i) {
+				continue
+			}
+			if tr.changedCells[j] == nil {
+				tr.changedCells[j] = make(map[int]bool)
+			}
+			tr.changedCells[j][i] = true
+		}
+	}
+	for _, r := range tr.prev {
+		if k := r.cell(tr.key); !seen[k] {
+			seen[k] = true
+			r.index, r.sep, r.removed = len(t.rows), false, true
+			t.rows = append(t.rows, r)
+			tr.ghosts++
+		}
+	}
+}
+
+// dropRemoved drops the removed rows appended by track.
+func (t *Table) dropRemoved() {
+	if t.tracker == nil || t.tracker.ghosts == 0 {
+		return
+	}
+	rows := t.rows[:0]
+	for _, r := range t.rows {
+		if !r.removed {
+			rows = append(rows, r)
+		}
+	}
+	t.rows = rows
+	t.tracker.ghosts = 0
+}
+
+// trackState is how a cell is marked by TrackRows.
+type trackState int
+
+// Track states
+const (
+	trackNone trackState = iota
+	trackAdded
+	trackChanged
+	trackRemoved
+)
+
+// trackState returns how column i of row j is marked by TrackRows.
+func (t *Table) trackState(j, i int) trackState {
+	tr := t.tracker
+	if tr == nil || j < 0 || j >= len(t.rows) {
+		return trackNone
+	}
+	switch {
+	case t.rows[j].removed:
+		return trackRemoved
+	case tr.new[j]:
+		return trackAdded
+	case tr.changedCells[j][i]:
+		return trackChanged
+	}
+	return trackNone
+}
+
+// trackFormat returns the format set by TrackRows for column i of row j, if any.
+func (t *Table) trackFormat(j, i int) FormatFunc {
+	switch t.trackState(j, i) {
+	case trackRemoved:
+		return t.tracker.removed
+	case trackAdded:
+		return t.tracker.added
+	case trackChanged:
+		return t.tracker.changed
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package table
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// wide holds the characters taking two terminal cells, like CJK ideographs, Hangul, fullwidth
+// forms and most emoji, after the East Asian Width property of Unicode.
+var wide = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x1100, 0x115f, 1},
+		{0x231a, 0x231b, 1},
+		{0x2329, 0x232a, 1},
+		{0x23e9, 0x23ec, 1},
+		{0x2e80, 0x303e, 1},
+		{0x3041, 0x33ff, 1},
+		{0x3400, 0x4dbf, 1},
+		{0x4e00, 0x9fff, 1},
+		{0xa000, 0xa4cf, 1},
+		{0xa960, 0xa97f, 1},
+		{0xac00, 0xd7a3, 1},
+		{0xf900, 0xfaff, 1},
+		{0xfe10, 0xfe19, 1},
+		{0xfe30, 0xfe6f, 1},
+		{0xff00, 0xff60, 1},
+		{0xffe0, 0xffe6, 1},
+	},
+	R32: []unicode.Range32{
+		{0x16fe0, 0x18cff, 1},
+		{0x1b000, 0x1b2ff, 1},
+		{0x1f004, 0x1f004, 1},
+		{0x1f0cf, 0x1f0cf, 1},
+		{0x1f18e, 0x1f18e, 1},
+		{0x1f191, 0x1f19a, 1},
+		{0x1f200, 0x1f251, 1},
+		{0x1f300, 0x1f64f, 1},
+		{0x1f680, 0x1f6ff, 1},
+		{0x1f7e0, 0x1f7eb, 1},
+		{0x1f90c, 0x1f9ff, 1},
+		{0x1fa70, 0x1faff, 1},
+		{0x20000, 0x2fffd, 1},
+		{0x30000, 0x3fffd, 1},
+	},
+}
+
+// runeWidth returns the number of terminal cells taken by r: 0 for control characters,
+// combining marks and other invisible characters, 2 for wide characters and 1 otherwise.
+func runeWidth(r rune) int {
+	switch {
+	case r < 0x20 || r == 0x7f:
+		return 0
+	case r < 0x300:
+		return 1
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case unicode.Is(wide, r):
+		return 2
+	}
+	return 1
+}
+
+// stringWidth returns the number of terminal cells taken by s, not counting ANSI escape sequences.
+// Wide characters like CJK ideographs count twice.
+func stringWidth(s string) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf || s[i] == '\x1b' {
+			return n + styledWidth(s[i:])
+		}
+		if s[i] >= 0x20 && s[i] != 0x7f {
+			n++
+		}
+	}
+	return n
+}
+
+// styledWidth returns the number of terminal cells taken by s, which may hold escape sequences
+// and characters of any width.
+func styledWidth(s string) int {
+	n := 0
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' {
+			i = skipEscape(s, i) + 1
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		n += runeWidth(r)
+		i += size
+	}
+	return n
+}
+
+// resetANSI ends all styles of ANSI escape sequences.
+const resetANSI = "\x1b[0m"
+
+// cutWidth returns the longest start of s taking at most w terminal cells. Escape sequences are
+// kept without being counted, and a reset is added if the cut leaves any of them unterminated.
+func cutWidth(s string, w int) string {
+	i, styled := cutIndex(s, w)
+	if styled && i < len(s) {
+		return s[:i] + resetANSI
+	}
+	return s[:i]
+}
+
+// cutIndex returns the length of the longest start of s taking at most w terminal cells, and
+// whether that start holds escape sequences.
+func cutIndex(s string, w int) (int, bool) {
+	n := 0
+	styled := false
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' {
+			i = skipEscape(s, i) + 1
+			styled = true
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		n += runeWidth(r)
+		if n > w {
+			return i, styled
+		}
+		i += size
+	}
+	return len(s), styled
+}
+
+// escapes returns the escape sequences of s, so that the style of the start of a cut string
+// can be carried over to the rest.
+func escapes(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\x1b' {
+			j := skipEscape(s, i)
+			b.WriteString(s[i : j+1])
+			i = j
+		}
+	}
+	return b.String()
+}
+
+// cutWidthEnd returns the longest end of s taking at most w terminal cells.
+// Unlike cutWidth, it does not handle escape sequences.
+func cutWidthEnd(s string, w int) string {
+	n := 0
+	for i := len(s); i > 0; {
+		r, size := utf8.DecodeLastRuneInString(s[:i])
+		n += runeWidth(r)
+		if n > w {
+			return s[i:]
+		}
+		i -= size
+	}
+	return s
+}